@@ -1,3 +1,13 @@
 data "bonsai_cluster" "get_by_slug" {
   slug = "dcek-group-llc-5240651189"
-}
\ No newline at end of file
+}
+
+# Clusters may also be looked up by name, which must match a single cluster.
+data "bonsai_cluster" "get_by_name" {
+  name       = "dcek-group-llc"
+  space_path = "omc/bonsai/us-east-1/common"
+}
+
+data "bonsai_cluster" "get_by_name_regex" {
+  name_regex = "^dcek-group-"
+}
@@ -1 +1,14 @@
 data "bonsai_clusters" "list" {}
+
+data "bonsai_clusters" "production" {
+  filter = {
+    name_regex = "^production-"
+    state      = "PROVISIONED"
+  }
+
+  sort_by = "name"
+}
+
+output "production_cluster_hosts" {
+  value = { for slug, cluster in data.bonsai_clusters.production.clusters_by_slug : slug => cluster.access.host }
+}
@@ -0,0 +1,27 @@
+# The cheapest shared plan for OpenSearch 2.x in a US region of AWS.
+data "bonsai_plan_recommendation" "search" {
+  service_type       = "opensearch"
+  version_constraint = "~> 2.6"
+
+  cloud_provider = "aws"
+  region         = ["aws-us-*"]
+
+  single_tenant      = false
+  max_price_in_cents = 10000
+}
+
+resource "bonsai_cluster" "search" {
+  name = "search"
+
+  plan = {
+    slug = data.bonsai_plan_recommendation.search.recommended.plan.slug
+  }
+
+  space = {
+    path = data.bonsai_plan_recommendation.search.recommended.space_paths[0]
+  }
+
+  release = {
+    slug = data.bonsai_plan_recommendation.search.recommended.release_slugs[0]
+  }
+}
@@ -1 +1,16 @@
 data "bonsai_plans" "list" {}
+
+# The cheapest plan available for OpenSearch 2.6 in us-east-1.
+data "bonsai_plans" "cheapest" {
+  filter = {
+    supports_release = "opensearch-2.6.0-mt"
+    supports_space   = "omc/bonsai/us-east-1/common"
+    single_tenant    = false
+  }
+
+  sort_by = "price"
+}
+
+output "cheapest_plan_slug" {
+  value = data.bonsai_plans.cheapest.plans[0].slug
+}
@@ -1,3 +1,10 @@
 data "bonsai_release" "get_by_slug" {
   slug = "elasticsearch-6.4.2"
-}
\ No newline at end of file
+}
+
+# The newest OpenSearch 2.x release, tracked without hardcoding its slug.
+data "bonsai_release" "latest_opensearch" {
+  latest             = true
+  service_type       = "opensearch"
+  version_constraint = "~> 2.6"
+}
@@ -1 +1,6 @@
 data "bonsai_releases" "list" {}
+
+data "bonsai_releases" "elasticsearch_7" {
+  service_type       = "elasticsearch"
+  version_constraint = ">= 7.10, < 8"
+}
@@ -1 +1,15 @@
 data "bonsai_spaces" "list" {}
+
+# The public, shared spaces in any US region of AWS.
+data "bonsai_spaces" "aws_us" {
+  filter = {
+    cloud_provider  = "aws"
+    region          = ["aws-us-*"]
+    private_network = false
+    tenancy         = "common"
+  }
+}
+
+output "aws_us_space_paths" {
+  value = [for s in data.bonsai_spaces.aws_us.spaces : s.path]
+}
@@ -16,4 +16,8 @@ provider "bonsai" {
   # Optionally omit this entry to get the value from the BONSAI_API_TOKEN
   # environment variable.
   api_token = var.bonsai_api_token
+
+  # Optionally read credentials not otherwise set from a named profile of the
+  # ~/.bonsai/credentials file, or the BONSAI_PROFILE environment variable.
+  # profile = "staging"
 }
@@ -0,0 +1,4 @@
+# Clusters can be imported by their slug. Credentials (access.user,
+# access.password and access.url) are only returned by the Bonsai API at
+# creation, and will be null for imported clusters.
+terraform import bonsai_cluster.test my-cluster-1234567890
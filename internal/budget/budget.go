@@ -0,0 +1,236 @@
+// Package budget estimates the monthly spend of the Clusters in a Bonsai
+// account, as they're planned, against a provider-level budget.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// Budget tracks the planned monthly spend of an account's Clusters against
+// MaxMonthlySpendCents.
+//
+// As Terraform plans each Cluster separately, and concurrently, the planned
+// spend is a running total: Clusters planned so far are counted at the cost
+// of their planned Plan, and all other Clusters in the account at the cost
+// of their current Plan. Once every Cluster has been planned, the total
+// covers the whole configuration.
+//
+// Each Cluster is counted once, however often it's planned: existing
+// Clusters are identified by their slug, and Clusters yet to be created by
+// their planned name, Space and Release.
+//
+// A Budget is safe for concurrent use.
+type Budget struct {
+	// MaxMonthlySpendCents is the maximum monthly spend, in cents.
+	MaxMonthlySpendCents int64
+	// WarnOnly reports exceeding the budget as a warning, rather than an
+	// error.
+	WarnOnly bool
+
+	// Clusters lists the Clusters in the account.
+	Clusters func(ctx context.Context) ([]bonsai.Cluster, error)
+	// Plans lists the Plans available to the account.
+	Plans func(ctx context.Context) ([]bonsai.Plan, error)
+
+	mu       sync.Mutex
+	loaded   bool
+	prices   map[string]float64
+	existing map[string]float64
+	// planned maps the key of each Cluster planned so far to the monthly
+	// cost of its planned Plan.
+	planned map[string]float64
+	// removed holds the slugs of the existing Clusters planned to be
+	// destroyed or replaced.
+	removed map[string]bool
+}
+
+// Change describes a planned change to one of the account's Clusters.
+type Change struct {
+	// Slug identifies the existing Cluster, and is empty for a Cluster which
+	// is yet to be created.
+	Slug string
+	// Name, SpacePath and ReleaseSlug identify a Cluster which is yet to be
+	// created.
+	Name        string
+	SpacePath   string
+	ReleaseSlug string
+	// PlanSlug is the slug of the Cluster's planned Plan, and is empty for a
+	// Cluster which is planned to be destroyed.
+	PlanSlug string
+	// Replace reports whether the existing Cluster is planned to be
+	// replaced. Terraform plans the Cluster replacing it separately, as a
+	// Cluster yet to be created.
+	Replace bool
+}
+
+// key identifies the changed Cluster among those planned.
+func (c Change) key() string {
+	if c.Slug != "" {
+		return "slug:" + c.Slug
+	}
+
+	return "new:" + strings.Join([]string{c.Name, c.SpacePath, c.ReleaseSlug}, "\x00")
+}
+
+// Spend describes the planned monthly spend, in cents.
+type Spend struct {
+	// Cluster is the monthly cost of the changed Cluster's planned Plan,
+	// which is zero for a Cluster planned to be destroyed or replaced.
+	Cluster int64
+	// Current is the changed Cluster's current monthly cost, which is zero
+	// for a Cluster which is yet to be created.
+	Current int64
+	// Planned is the monthly cost of the other Clusters planned so far.
+	Planned int64
+	// PlannedClusters is the number of the other Clusters planned so far.
+	PlannedClusters int
+	// Unmanaged is the current monthly cost of the account's Clusters which
+	// haven't been planned.
+	Unmanaged int64
+	// UnmanagedClusters is the number of the account's Clusters which
+	// haven't been planned.
+	UnmanagedClusters int
+}
+
+// Total returns the total planned monthly spend, in cents.
+func (s Spend) Total() int64 {
+	return s.Cluster + s.Planned + s.Unmanaged
+}
+
+// MonthlyCostCents normalizes the price of a Plan to a monthly cost.
+func MonthlyCostCents(p bonsai.Plan) float64 {
+	months := max(p.BillingIntervalInMonths, 1)
+	return float64(p.PriceInCents) / float64(months)
+}
+
+// Plan records the change to a Cluster, returning the running total of the
+// planned monthly spend, and whether it exceeds the budget.
+//
+// The planned monthly spend only exceeds the budget if it also exceeds the
+// account's current monthly spend, such that Clusters may still be planned
+// without changes, downgraded or replaced while the account's spend is
+// already over budget.
+func (b *Budget) Plan(ctx context.Context, change Change) (Spend, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.load(ctx); err != nil {
+		return Spend{}, false, err
+	}
+
+	key := change.key()
+
+	var cost float64
+	switch {
+	case change.PlanSlug == "" || change.Replace:
+		delete(b.planned, key)
+		if change.Slug != "" {
+			b.removed[change.Slug] = true
+		}
+	default:
+		var ok bool
+		cost, ok = b.prices[change.PlanSlug]
+		if !ok {
+			return Spend{}, false, fmt.Errorf("no price is known for plan %q", change.PlanSlug)
+		}
+
+		b.planned[key] = cost
+		delete(b.removed, change.Slug)
+	}
+
+	spend := b.spend(key)
+	spend.Cluster = int64(math.Round(cost))
+	spend.Current = int64(math.Round(b.existing[change.Slug]))
+
+	var account float64
+	for _, existing := range b.existing {
+		account += existing
+	}
+
+	total := spend.Total()
+	return spend, total > b.MaxMonthlySpendCents && total > int64(math.Round(account)), nil
+}
+
+// spend totals the planned monthly spend of the Clusters other than the one
+// identified by key.
+func (b *Budget) spend(key string) Spend {
+	var (
+		s                  Spend
+		planned, unmanaged float64
+	)
+
+	for k, cost := range b.planned {
+		if k == key {
+			continue
+		}
+		planned += cost
+		s.PlannedClusters++
+	}
+
+	for slug, cost := range b.existing {
+		if _, ok := b.planned[Change{Slug: slug}.key()]; ok || b.removed[slug] {
+			continue
+		}
+		unmanaged += cost
+		s.UnmanagedClusters++
+	}
+
+	s.Planned = int64(math.Round(planned))
+	s.Unmanaged = int64(math.Round(unmanaged))
+
+	return s
+}
+
+// load fetches Plan prices and the account's existing Clusters, once.
+func (b *Budget) load(ctx context.Context) error {
+	if b.loaded {
+		return nil
+	}
+
+	plans, err := b.Plans(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list plans: %w", err)
+	}
+
+	clusters, err := b.Clusters(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list clusters: %w", err)
+	}
+
+	b.prices = make(map[string]float64, len(plans))
+	for _, p := range plans {
+		b.prices[p.Slug] = MonthlyCostCents(p)
+	}
+
+	b.existing = make(map[string]float64, len(clusters))
+	for _, c := range clusters {
+		if c.State == bonsai.ClusterStateDeprovisioned || c.State == bonsai.ClusterStateDeprovisioning {
+			continue
+		}
+
+		// Clusters' own Plans may omit pricing, so prefer the catalog's.
+		cost, ok := b.prices[c.Plan.Slug]
+		if !ok {
+			cost = MonthlyCostCents(c.Plan)
+		}
+		b.existing[c.Slug] = cost
+	}
+
+	b.planned = map[string]float64{}
+	b.removed = map[string]bool{}
+	b.loaded = true
+
+	return nil
+}
+
+// FormatCents formats an amount in cents for diagnostics, such as
+// "12345 cents ($123.45)".
+func FormatCents(cents int64) string {
+	return fmt.Sprintf("%d cents ($%d.%02d)", cents, cents/100, cents%100)
+}
@@ -0,0 +1,194 @@
+package budget_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetTestSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	ctx context.Context
+}
+
+func TestBudgetTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetTestSuite))
+}
+
+func (s *BudgetTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ctx = context.Background()
+}
+
+// newBudget returns a Budget of max cents, for an account with a
+// "standard-sm" Cluster and a "sandbox" Cluster.
+func (s *BudgetTestSuite) newBudget(max int64) *budget.Budget {
+	return &budget.Budget{
+		MaxMonthlySpendCents: max,
+		Plans: func(_ context.Context) ([]bonsai.Plan, error) {
+			return []bonsai.Plan{
+				{Slug: "sandbox", PriceInCents: 0, BillingIntervalInMonths: 1},
+				{Slug: "standard-sm", PriceInCents: 5000, BillingIntervalInMonths: 1},
+				{Slug: "standard-sm-annual", PriceInCents: 54000, BillingIntervalInMonths: 12},
+			}, nil
+		},
+		Clusters: func(_ context.Context) ([]bonsai.Cluster, error) {
+			return []bonsai.Cluster{
+				{Slug: "existing-1234", Plan: bonsai.Plan{Slug: "standard-sm"}, State: bonsai.ClusterStateProvisioned},
+				{Slug: "sandbox-1234", Plan: bonsai.Plan{Slug: "sandbox"}, State: bonsai.ClusterStateProvisioned},
+				{Slug: "gone-1234", Plan: bonsai.Plan{Slug: "standard-sm"}, State: bonsai.ClusterStateDeprovisioned},
+			}, nil
+		},
+	}
+}
+
+func (s *BudgetTestSuite) TestMonthlyCostCents() {
+	s.InDelta(5000, budget.MonthlyCostCents(bonsai.Plan{PriceInCents: 5000, BillingIntervalInMonths: 1}), 0.001)
+	s.InDelta(4500, budget.MonthlyCostCents(bonsai.Plan{PriceInCents: 54000, BillingIntervalInMonths: 12}), 0.001)
+	s.InDelta(5000, budget.MonthlyCostCents(bonsai.Plan{PriceInCents: 5000}), 0.001)
+}
+
+func (s *BudgetTestSuite) TestPlan_CountsUnmanagedClusters() {
+	b := s.newBudget(10000)
+
+	spend, over, err := b.Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "standard-sm-annual"})
+	s.NoError(err)
+	s.False(over)
+	s.Equal(budget.Spend{Cluster: 4500, Unmanaged: 5000, UnmanagedClusters: 2}, spend)
+}
+
+func (s *BudgetTestSuite) TestPlan_NewClustersAddUp() {
+	b := s.newBudget(12000)
+
+	// Each new Cluster fits within the budget alone, but not together.
+	spend, over, err := b.Plan(s.ctx, budget.Change{Name: "first", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.False(over)
+	s.Equal(int64(10000), spend.Total())
+
+	spend, over, err = b.Plan(s.ctx, budget.Change{Name: "second", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.True(over)
+	s.Equal(budget.Spend{Cluster: 5000, Planned: 5000, PlannedClusters: 1, Unmanaged: 5000, UnmanagedClusters: 2}, spend)
+}
+
+func (s *BudgetTestSuite) TestPlan_ReplannedClustersCountOnce() {
+	b := s.newBudget(12000)
+
+	for range 2 {
+		spend, over, err := b.Plan(s.ctx, budget.Change{Name: "new", SpacePath: "omc/bonsai/us-east-1/common", PlanSlug: "standard-sm"})
+		s.NoError(err)
+		s.False(over)
+		s.Equal(budget.Spend{Cluster: 5000, Unmanaged: 5000, UnmanagedClusters: 2}, spend)
+	}
+
+	for range 2 {
+		spend, over, err := b.Plan(s.ctx, budget.Change{Slug: "existing-1234", PlanSlug: "standard-sm"})
+		s.NoError(err)
+		s.False(over)
+		s.Equal(budget.Spend{Cluster: 5000, Current: 5000, Planned: 5000, PlannedClusters: 1, UnmanagedClusters: 1}, spend)
+	}
+}
+
+func (s *BudgetTestSuite) TestPlan_ManagedClustersReplaceCurrentCost() {
+	b := s.newBudget(5000)
+
+	// Downgrading the existing Cluster frees budget for a new one.
+	spend, over, err := b.Plan(s.ctx, budget.Change{Slug: "existing-1234", PlanSlug: "sandbox"})
+	s.NoError(err)
+	s.False(over)
+	s.Equal(budget.Spend{Cluster: 0, Current: 5000, UnmanagedClusters: 1}, spend)
+
+	spend, over, err = b.Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.False(over)
+	s.Equal(int64(5000), spend.Total())
+
+	// Destroying a Cluster removes its cost.
+	spend, _, err = b.Plan(s.ctx, budget.Change{Slug: "sandbox-1234"})
+	s.NoError(err)
+	s.Equal(budget.Spend{Planned: 5000, PlannedClusters: 2}, spend)
+}
+
+func (s *BudgetTestSuite) TestPlan_OnlyRaisedSpendExceeds() {
+	b := s.newBudget(1000)
+
+	// The account is already over budget, yet planning the Cluster without
+	// changes, or downgrading it, succeeds.
+	spend, over, err := b.Plan(s.ctx, budget.Change{Slug: "existing-1234", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.False(over)
+	s.Equal(int64(5000), spend.Total())
+
+	_, over, err = b.Plan(s.ctx, budget.Change{Slug: "existing-1234", PlanSlug: "standard-sm-annual"})
+	s.NoError(err)
+	s.False(over)
+
+	// Adding a Cluster raises the account's spend above its current 5000.
+	spend, over, err = b.Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.True(over)
+	s.Equal(int64(9500), spend.Total())
+}
+
+func (s *BudgetTestSuite) TestPlan_Replacement() {
+	b := s.newBudget(5000)
+
+	// Terraform plans the replacement of a Cluster, then the Cluster
+	// replacing it as one yet to be created, which is counted once.
+	replaced := budget.Change{Slug: "existing-1234", Name: "existing", PlanSlug: "standard-sm", Replace: true}
+	replacing := budget.Change{Name: "existing", SpacePath: "omc/bonsai/eu-west-1/common", PlanSlug: "standard-sm"}
+
+	spend, over, err := b.Plan(s.ctx, replaced)
+	s.NoError(err)
+	s.False(over)
+	s.Equal(budget.Spend{Cluster: 0, Current: 5000, UnmanagedClusters: 1}, spend)
+
+	spend, over, err = b.Plan(s.ctx, replacing)
+	s.NoError(err)
+	s.False(over)
+	s.Equal(budget.Spend{Cluster: 5000, UnmanagedClusters: 1}, spend)
+
+	// Planning either again counts neither twice.
+	spend, over, err = b.Plan(s.ctx, replaced)
+	s.NoError(err)
+	s.False(over)
+	s.Equal(int64(5000), spend.Total())
+
+	spend, over, err = b.Plan(s.ctx, replacing)
+	s.NoError(err)
+	s.False(over)
+	s.Equal(int64(5000), spend.Total())
+
+	// Other new Clusters are still counted alongside it.
+	_, over, err = b.Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "standard-sm"})
+	s.NoError(err)
+	s.True(over)
+}
+
+func (s *BudgetTestSuite) TestPlan_UnknownPlan() {
+	_, _, err := s.newBudget(5000).Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "enterprise-xl"})
+	s.ErrorContains(err, `no price is known for plan "enterprise-xl"`)
+}
+
+func (s *BudgetTestSuite) TestPlan_ListError() {
+	b := s.newBudget(5000)
+	b.Clusters = func(_ context.Context) ([]bonsai.Cluster, error) {
+		return nil, errors.New("unavailable")
+	}
+
+	_, _, err := b.Plan(s.ctx, budget.Change{Name: "new", PlanSlug: "sandbox"})
+	s.ErrorContains(err, "unable to list clusters: unavailable")
+}
+
+func (s *BudgetTestSuite) TestFormatCents() {
+	s.Equal("0 cents ($0.00)", budget.FormatCents(0))
+	s.Equal("12345 cents ($123.45)", budget.FormatCents(12345))
+}
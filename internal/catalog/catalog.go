@@ -0,0 +1,160 @@
+// Package catalog caches the Plans, Spaces and Releases available to a
+// Bonsai account, such that the data sources and plan-time validation of a
+// provider instance share the results of a single request for each.
+package catalog
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// DefaultTTL is the duration for which a listing is cached, by default.
+const DefaultTTL = 5 * time.Minute
+
+// Catalog lists, and looks up, the Plans, Spaces and Releases available to
+// a Bonsai account, caching each listing for its TTL.
+//
+// Lookups are served from the cached listing, falling back to requesting the
+// item itself should it not be listed. Failed requests aren't cached.
+//
+// A Catalog is safe for concurrent use; concurrent requests for a listing
+// which isn't cached share a single request to the Bonsai API.
+type Catalog struct {
+	client *bonsai.Client
+	ttl    time.Duration
+
+	plans    listing[bonsai.Plan]
+	spaces   listing[bonsai.Space]
+	releases listing[bonsai.Release]
+}
+
+// New returns a Catalog requesting listings with client, caching them for
+// ttl. Caching is disabled if ttl isn't positive, such that every call
+// makes a request.
+func New(client *bonsai.Client, ttl time.Duration) *Catalog {
+	return &Catalog{
+		client: client,
+		ttl:    ttl,
+
+		plans:    listing[bonsai.Plan]{name: "plans", fetch: client.Plan.All},
+		spaces:   listing[bonsai.Space]{name: "spaces", fetch: client.Space.All},
+		releases: listing[bonsai.Release]{name: "releases", fetch: client.Release.All},
+	}
+}
+
+// Cached reports whether listings are cached.
+func (c *Catalog) Cached() bool {
+	return c.ttl > 0
+}
+
+// Plans lists the Plans available to the account.
+func (c *Catalog) Plans(ctx context.Context) ([]bonsai.Plan, error) {
+	return c.plans.get(ctx, c.ttl)
+}
+
+// Plan looks up the Plan identified by slug.
+func (c *Catalog) Plan(ctx context.Context, slug string) (bonsai.Plan, error) {
+	return lookup(ctx, c, c.Plans, c.client.Plan.GetBySlug, func(p bonsai.Plan) bool {
+		return p.Slug == slug
+	}, slug)
+}
+
+// Spaces lists the Spaces available to the account.
+func (c *Catalog) Spaces(ctx context.Context) ([]bonsai.Space, error) {
+	return c.spaces.get(ctx, c.ttl)
+}
+
+// Space looks up the Space identified by path.
+func (c *Catalog) Space(ctx context.Context, path string) (bonsai.Space, error) {
+	return lookup(ctx, c, c.Spaces, c.client.Space.GetByPath, func(s bonsai.Space) bool {
+		return s.Path == path
+	}, path)
+}
+
+// Releases lists the Releases available to the account.
+func (c *Catalog) Releases(ctx context.Context) ([]bonsai.Release, error) {
+	return c.releases.get(ctx, c.ttl)
+}
+
+// Release looks up the Release identified by slug.
+func (c *Catalog) Release(ctx context.Context, slug string) (bonsai.Release, error) {
+	return lookup(ctx, c, c.Releases, c.client.Release.GetBySlug, func(r bonsai.Release) bool {
+		return r.Slug == slug
+	}, slug)
+}
+
+// lookup finds the item identified by id in the cached listing, or requests
+// it with get should caching be disabled, or the item not be listed.
+func lookup[T any](
+	ctx context.Context,
+	c *Catalog,
+	list func(context.Context) ([]T, error),
+	get func(context.Context, string) (T, error),
+	match func(T) bool,
+	id string,
+) (T, error) {
+	if !c.Cached() {
+		return get(ctx, id)
+	}
+
+	items, err := list(ctx)
+	if err == nil {
+		if i := slices.IndexFunc(items, match); i >= 0 {
+			return items[i], nil
+		}
+	}
+
+	return get(ctx, id)
+}
+
+// listing caches the result of listing a kind of catalog item.
+type listing[T any] struct {
+	name  string
+	fetch func(context.Context) ([]T, error)
+
+	mu        sync.Mutex
+	items     []T
+	fetchedAt time.Time
+}
+
+// get returns the cached items if they're younger than ttl, fetching them
+// otherwise. The returned slice may be modified by the caller.
+func (l *listing[T]) get(ctx context.Context, ttl time.Duration) ([]T, error) {
+	if ttl <= 0 {
+		return l.fetch(ctx)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.items != nil && time.Since(l.fetchedAt) < ttl {
+		tflog.Trace(ctx, "Using cached Bonsai catalog", map[string]interface{}{
+			"catalog": l.name,
+			"age":     time.Since(l.fetchedAt).String(),
+		})
+		return slices.Clone(l.items), nil
+	}
+
+	items, err := l.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+	l.items, l.fetchedAt = items, time.Now()
+
+	tflog.Debug(ctx, "Cached Bonsai catalog", map[string]interface{}{
+		"catalog": l.name,
+		"items":   len(items),
+		"ttl":     ttl.String(),
+	})
+
+	return slices.Clone(items), nil
+}
@@ -0,0 +1,198 @@
+package catalog_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+
+	ctx context.Context
+
+	listRequests atomic.Int64
+	getRequests  atomic.Int64
+	failing      atomic.Bool
+}
+
+func TestCatalogTestSuite(t *testing.T) {
+	suite.Run(t, &CatalogTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *CatalogTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ctx = context.Background()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		s.listRequests.Add(1)
+
+		if s.failing.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors": ["unavailable"], "status": 400}`))
+			return
+		}
+
+		// Slow responses let concurrent requests overlap.
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "sandbox",
+						"name": "Sandbox",
+						"price_in_cents": 0,
+						"billing_interval_in_months": 1,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath+"/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		s.getRequests.Add(1)
+
+		if slug := chi.URLParam(r, "slug"); slug != "legacy-sm" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": ["not found"], "status": 404}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"slug": "legacy-sm",
+				"name": "Legacy Small",
+				"price_in_cents": 2500,
+				"billing_interval_in_months": 1,
+				"available_releases": [],
+				"available_spaces": []
+			}
+		`))
+	})
+}
+
+func (s *CatalogTestSuite) SetupTest() {
+	s.listRequests.Store(0)
+	s.getRequests.Store(0)
+	s.failing.Store(false)
+}
+
+func (s *CatalogTestSuite) TestCatalog_CachesListing() {
+	c := catalog.New(s.Client, time.Minute)
+
+	for range 3 {
+		plans, err := c.Plans(s.ctx)
+		s.NoError(err)
+		s.Len(plans, 1)
+	}
+
+	plan, err := c.Plan(s.ctx, "sandbox")
+	s.NoError(err)
+	s.Equal("Sandbox", plan.Name)
+
+	s.Equal(int64(1), s.listRequests.Load())
+	s.Zero(s.getRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_LookupFallsBackToRequest() {
+	c := catalog.New(s.Client, time.Minute)
+
+	plan, err := c.Plan(s.ctx, "legacy-sm")
+	s.NoError(err)
+	s.Equal("Legacy Small", plan.Name)
+
+	_, err = c.Plan(s.ctx, "enterprise-xl")
+	s.ErrorIs(err, bonsai.ErrHTTPStatusNotFound)
+
+	s.Equal(int64(1), s.listRequests.Load())
+	s.Equal(int64(2), s.getRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_Expires() {
+	c := catalog.New(s.Client, time.Millisecond)
+
+	_, err := c.Plans(s.ctx)
+	s.NoError(err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = c.Plans(s.ctx)
+	s.NoError(err)
+
+	s.Equal(int64(2), s.listRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_Disabled() {
+	c := catalog.New(s.Client, 0)
+	s.False(c.Cached())
+
+	for range 2 {
+		_, err := c.Plans(s.ctx)
+		s.NoError(err)
+
+		_, err = c.Plan(s.ctx, "sandbox")
+		s.Error(err)
+	}
+
+	s.Equal(int64(2), s.listRequests.Load())
+	s.Equal(int64(2), s.getRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_ErrorsAreNotCached() {
+	c := catalog.New(s.Client, time.Minute)
+
+	s.failing.Store(true)
+	_, err := c.Plans(s.ctx)
+	s.Error(err)
+
+	s.failing.Store(false)
+	plans, err := c.Plans(s.ctx)
+	s.NoError(err)
+	s.Len(plans, 1)
+
+	s.Equal(int64(2), s.listRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_ConcurrentCallsShareRequest() {
+	c := catalog.New(s.Client, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Plans(s.ctx)
+			s.Assert().NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int64(1), s.listRequests.Load())
+}
+
+func (s *CatalogTestSuite) TestCatalog_ReturnsCopies() {
+	c := catalog.New(s.Client, time.Minute)
+
+	plans, err := c.Plans(s.ctx)
+	s.NoError(err)
+	plans[0].Slug = "modified"
+
+	plans, err = c.Plans(s.ctx)
+	s.NoError(err)
+	s.Equal("sandbox", plans[0].Slug)
+}
@@ -0,0 +1,123 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/plan"
+)
+
+// knownString reports whether v holds a value which may be validated.
+func knownString(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+// formatAlternatives lists the valid alternatives for a diagnostic.
+func formatAlternatives(alternatives []string) string {
+	if len(alternatives) == 0 {
+		return "none"
+	}
+
+	sorted := make([]string, len(alternatives))
+	copy(sorted, alternatives)
+	sort.Strings(sorted)
+
+	return `"` + strings.Join(sorted, `", "`) + `"`
+}
+
+// validateCompatibility checks that the desired Plan exists, and that it's
+// available in the desired Space and for the desired Release.
+//
+// Validation is skipped when none of the attributes changed from the prior
+// state, or while any of them are unknown.
+func (r *resource) validateCompatibility(ctx context.Context, desired resourceModel, prior *resourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !knownString(desired.Plan.Slug) {
+		return diags
+	}
+
+	if prior != nil &&
+		desired.Plan.Slug.Equal(prior.Plan.Slug) &&
+		desired.Space.Path.Equal(prior.Space.Path) &&
+		desired.Release.Slug.Equal(prior.Release.Slug) {
+		return diags
+	}
+
+	planSlug := desired.Plan.Slug.ValueString()
+	p, err := r.catalog.Plan(ctx, planSlug)
+	if err != nil {
+		if !errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+			diags.AddWarning(
+				fmt.Sprintf("Unable to validate Bonsai Plan (%s) compatibility", planSlug),
+				"The plan couldn't be retrieved from the Bonsai API, so its "+
+					"compatibility with the chosen space and release will be "+
+					"checked when the plan is applied.\n\n"+
+					"Bonsai Client Error: "+err.Error(),
+			)
+			return diags
+		}
+
+		alternatives := []string{}
+		if plans, err := r.catalog.Plans(ctx); err == nil {
+			for _, p := range plans {
+				alternatives = append(alternatives, p.Slug)
+			}
+		}
+
+		diags.AddAttributeError(
+			path.Root("plan").AtName("slug"),
+			"Invalid Bonsai Plan",
+			fmt.Sprintf(
+				"Plan %q was not found. Available plans: %s.",
+				planSlug,
+				formatAlternatives(alternatives),
+			),
+		)
+		return diags
+	}
+
+	compatibility, err := plan.NewCompatibility(ctx, p)
+	if err != nil {
+		diags.AddWarning(
+			fmt.Sprintf("Unable to validate Bonsai Plan (%s) compatibility", planSlug),
+			err.Error(),
+		)
+		return diags
+	}
+
+	if spacePath := desired.Space.Path; knownString(spacePath) && !compatibility.SupportsSpace(spacePath.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("space").AtName("path"),
+			"Incompatible Bonsai Space",
+			fmt.Sprintf(
+				"Space %q is not available for plan %q. Available spaces for this plan: %s.",
+				spacePath.ValueString(),
+				planSlug,
+				formatAlternatives(compatibility.Spaces),
+			),
+		)
+	}
+
+	if releaseSlug := desired.Release.Slug; knownString(releaseSlug) && !compatibility.SupportsRelease(releaseSlug.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("release").AtName("slug"),
+			"Incompatible Bonsai Release",
+			fmt.Sprintf(
+				"Release %q is not available for plan %q. Available releases for this plan: %s.",
+				releaseSlug.ValueString(),
+				planSlug,
+				formatAlternatives(compatibility.Releases),
+			),
+		)
+	}
+
+	return diags
+}
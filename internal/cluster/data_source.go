@@ -3,13 +3,19 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // dataSource is the data source implementation.
@@ -36,6 +42,41 @@ type dataSourceModel struct {
 	State   stateModel   `tfsdk:"state"`
 }
 
+// lookupModel maps the bonsai_cluster data source schema data; a cluster's
+// dataSourceModel with the arguments looking it up.
+type lookupModel struct {
+	Name types.String `tfsdk:"name"`
+	Slug types.String `tfsdk:"slug"`
+	URI  types.String `tfsdk:"uri"`
+
+	Plan    planModel    `tfsdk:"plan"`
+	Release releaseModel `tfsdk:"release"`
+	Space   spaceModel   `tfsdk:"space"`
+	Stats   statsModel   `tfsdk:"stats"`
+	Access  accessModel  `tfsdk:"access"`
+	State   stateModel   `tfsdk:"state"`
+
+	NameRegex types.String `tfsdk:"name_regex"`
+	SpacePath types.String `tfsdk:"space_path"`
+}
+
+// setCluster sets the attributes describing the cluster.
+func (m *lookupModel) setCluster(c dataSourceModel) {
+	m.Name = c.Name
+	m.Slug = c.Slug
+	m.URI = c.URI
+	m.Plan = c.Plan
+	m.Release = c.Release
+	m.Space = c.Space
+	m.Stats = c.Stats
+	m.Access = c.Access
+	m.State = c.State
+}
+
+// maxListedCandidates is the most clusters listed by the diagnostics of a
+// lookup matching no cluster, or several.
+const maxListedCandidates = 20
+
 func dataSourceConvert(c bonsai.Cluster) dataSourceModel {
 	return dataSourceModel{
 		Name: types.StringValue(c.Name),
@@ -240,64 +281,210 @@ func (d *dataSource) Metadata(_ context.Context, req tfds.MetadataRequest, resp
 
 // Schema defines the schema for the data source.
 func (d *dataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *tfds.SchemaResponse) {
+	attributes := dataSourceSchemaAttributes()
+	attributes["slug"] = dschema.StringAttribute{
+		MarkdownDescription: "A unique, machine-readable name for the " +
+			"cluster. A cluster slug is based its name at creation, to " +
+			"which a random integer is concatenated. One of `slug`, `name` " +
+			"or `name_regex` must be set.",
+		Computed: true,
+		Optional: true,
+	}
+	attributes["name"] = dschema.StringAttribute{
+		MarkdownDescription: "The human-readable name of the cluster. When " +
+			"set, the cluster is looked up by its exact name, which must " +
+			"match a single cluster, optionally within `space_path`.",
+		Computed: true,
+		Optional: true,
+	}
+	attributes["name_regex"] = dschema.StringAttribute{
+		MarkdownDescription: "A regular expression which the name of the " +
+			"cluster must match, such as `^production-`. It must match a " +
+			"single cluster, optionally within `space_path`.",
+		Optional: true,
+	}
+	attributes["space_path"] = dschema.StringAttribute{
+		MarkdownDescription: "The path of the space in which to look up the " +
+			"cluster by `name` or `name_regex`, such as " +
+			"`omc/bonsai/us-east-1/common`.",
+		Optional: true,
+	}
+
 	resp.Schema = dschema.Schema{
-		Attributes:          dataSourceSchemaAttributes(),
+		Attributes:          attributes,
 		MarkdownDescription: dataSourceMarkdownDescription,
 	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *dataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
-	var state dataSourceModel
+	ctx = logging.MaskCredentials(ctx)
+
+	var state lookupModel
 
-	// Fetch requested Slug from context
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("slug"), &state.Slug)...)
+	// Fetch the requested lookup arguments from context
+	for argument, value := range map[string]*types.String{
+		"slug":       &state.Slug,
+		"name":       &state.Name,
+		"name_regex": &state.NameRegex,
+		"space_path": &state.SpacePath,
+	} {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(argument), value)...)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.Slug.IsNull() {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
-			"expected 'slug' option to be set",
+	var (
+		apiResp bonsai.Cluster
+		err     error
+	)
+
+	switch {
+	case !state.Slug.IsNull() && (!state.Name.IsNull() || !state.NameRegex.IsNull() || !state.SpacePath.IsNull()):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("slug"),
+			"Conflicting Bonsai Cluster Lookup",
+			"'slug' identifies a single cluster, so 'name', 'name_regex' and 'space_path' may not also be set.",
 		)
 		return
-	}
 
-	apiResp, err := d.client.Cluster.GetBySlug(ctx, state.Slug.ValueString())
+	case !state.Name.IsNull() && !state.NameRegex.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Conflicting Bonsai Cluster Lookup",
+			"Only one of 'name' or 'name_regex' may be set.",
+		)
+		return
 
-	if err != nil {
+	case !state.Name.IsNull() || !state.NameRegex.IsNull():
+		var diags diag.Diagnostics
+		apiResp, diags = d.lookup(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+	case state.Slug.IsNull():
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
-			err.Error(),
+			"expected 'slug', 'name' or 'name_regex' option to be set",
 		)
 		return
+
+	default:
+		apiResp, err = d.client.Cluster.GetBySlug(ctx, state.Slug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
+				err.Error(),
+			)
+			return
+		}
 	}
 
 	// Map response body to dataSourceModel
-	state = dataSourceConvert(apiResp)
+	state.setCluster(dataSourceConvert(apiResp))
 
-	tflog.Debug(ctx, fmt.Sprintf("Cluster converted: %+v", state))
+	tflog.Debug(ctx, "Cluster converted", clusterLogFields(apiResp))
 	// Set state
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// lookup returns the single cluster matching the name or name_regex of the
+// lookup, within its space_path if set. Clusters which have been
+// deprovisioned are ignored.
+func (d *dataSource) lookup(ctx context.Context, state lookupModel) (bonsai.Cluster, diag.Diagnostics) {
+	nameRegex, diags := filter.Regexp(state.NameRegex, path.Root("name_regex"))
+	if diags.HasError() {
+		return bonsai.Cluster{}, diags
+	}
+
+	clusters, err := d.client.Cluster.All(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Bonsai Clusters from the Bonsai API",
+			err.Error(),
+		)
+		return bonsai.Cluster{}, diags
+	}
+
+	clusters = slices.DeleteFunc(clusters, func(c bonsai.Cluster) bool {
+		return c.State == bonsai.ClusterStateDeprovisioned || !filter.Equal(state.SpacePath, c.Space.Path)
+	})
+	candidates := clusters
+
+	matches := slices.DeleteFunc(slices.Clone(clusters), func(c bonsai.Cluster) bool {
+		if nameRegex != nil {
+			return !nameRegex.MatchString(c.Name)
+		}
+		return c.Name != state.Name.ValueString()
+	})
+
+	criteria := fmt.Sprintf("name %q", state.Name.ValueString())
+	if nameRegex != nil {
+		criteria = fmt.Sprintf("a name matching %q", state.NameRegex.ValueString())
+	}
+	if !state.SpacePath.IsNull() {
+		criteria += fmt.Sprintf(" in space %q", state.SpacePath.ValueString())
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], diags
+
+	case 0:
+		detail := fmt.Sprintf("No cluster on your account has %s.", criteria)
+		if len(candidates) > 0 {
+			detail += " The candidates are:\n\n" + describeClusters(candidates)
+		}
+		diags.AddError("No Bonsai Cluster Matches the Lookup", detail)
+
+	default:
+		diags.AddError(
+			"Multiple Bonsai Clusters Match the Lookup",
+			fmt.Sprintf(
+				"%d clusters on your account have %s; set 'slug', or narrow the lookup "+
+					"with 'space_path' or a more specific 'name_regex'. The matching clusters are:\n\n%s",
+				len(matches), criteria, describeClusters(matches),
+			),
+		)
+	}
+
+	return bonsai.Cluster{}, diags
+}
+
+// describeClusters lists the clusters, one per line, for use in diagnostics.
+func describeClusters(clusters []bonsai.Cluster) string {
+	var b strings.Builder
+
+	for i, c := range clusters {
+		if i == maxListedCandidates {
+			fmt.Fprintf(&b, "  - ... and %d more\n", len(clusters)-i)
+			break
+		}
+		fmt.Fprintf(&b, "  - %s (name %q, space %q, state %s)\n", c.Slug, c.Name, c.Space.Path, c.State)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *dataSource) Configure(_ context.Context, req tfds.ConfigureRequest, resp *tfds.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Cluster
+	d.client = &data.Client.Cluster
 }
@@ -3,15 +3,49 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // listDataSourceModel maps the data source schema data.
 type listDataSourceModel struct {
-	Clusters []dataSourceModel `tfsdk:"clusters"`
+	Filter    *listFilterModel `tfsdk:"filter"`
+	SortBy    types.String     `tfsdk:"sort_by"`
+	SortOrder types.String     `tfsdk:"sort_order"`
+
+	Clusters       []dataSourceModel          `tfsdk:"clusters"`
+	Slugs          []string                   `tfsdk:"slugs"`
+	ClustersBySlug map[string]dataSourceModel `tfsdk:"clusters_by_slug"`
+}
+
+// listFilterModel maps the data source's filter attribute.
+type listFilterModel struct {
+	NameRegex   types.String `tfsdk:"name_regex"`
+	State       types.String `tfsdk:"state"`
+	PlanSlug    types.String `tfsdk:"plan_slug"`
+	SpacePath   types.String `tfsdk:"space_path"`
+	ReleaseSlug types.String `tfsdk:"release_slug"`
+	ServiceType types.String `tfsdk:"service_type"`
+}
+
+// listSortKeys are the sort_by values accepted by the data source.
+var listSortKeys = map[string]func(a, b bonsai.Cluster) int{
+	"name":         filter.ByString(func(c bonsai.Cluster) string { return c.Name }),
+	"slug":         filter.ByString(func(c bonsai.Cluster) string { return c.Slug }),
+	"state":        filter.ByString(func(c bonsai.Cluster) string { return string(c.State) }),
+	"plan_slug":    filter.ByString(func(c bonsai.Cluster) string { return c.Plan.Slug }),
+	"space_path":   filter.ByString(func(c bonsai.Cluster) string { return c.Space.Path }),
+	"release_slug": filter.ByString(func(c bonsai.Cluster) string { return c.Release.Slug }),
 }
 
 // listDataSource is the data source implementation.
@@ -39,6 +73,50 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 	resp.Schema = dschema.Schema{
 		MarkdownDescription: listDataSourceMarkdownDescription,
 		Attributes: map[string]dschema.Attribute{
+			"filter": dschema.SingleNestedAttribute{
+				MarkdownDescription: "Criteria which the listed clusters must all match. " +
+					"Any cluster matches criteria which aren't set.",
+				Optional: true,
+				Attributes: map[string]dschema.Attribute{
+					"name_regex": dschema.StringAttribute{
+						MarkdownDescription: "A regular expression matching the cluster's name, " +
+							"such as `^production-`.",
+						Optional: true,
+					},
+					"state": dschema.StringAttribute{
+						MarkdownDescription: "The cluster's state, such as `PROVISIONED`. Case-insensitive.",
+						Optional:            true,
+					},
+					"plan_slug": dschema.StringAttribute{
+						MarkdownDescription: "The slug of the cluster's plan, such as `sandbox`.",
+						Optional:            true,
+					},
+					"space_path": dschema.StringAttribute{
+						MarkdownDescription: "The path of the cluster's space, such as `omc/bonsai/us-east-1/common`.",
+						Optional:            true,
+					},
+					"release_slug": dschema.StringAttribute{
+						MarkdownDescription: "The slug of the cluster's release, such as `opensearch-2.6.0-mt`.",
+						Optional:            true,
+					},
+					"service_type": dschema.StringAttribute{
+						MarkdownDescription: "The service type of the cluster's release, such as `opensearch`.",
+						Optional:            true,
+					},
+				},
+			},
+			"sort_by": dschema.StringAttribute{
+				MarkdownDescription: "The attribute to sort the listed clusters by; one of `" +
+					strings.Join(filter.SortKeys(listSortKeys), "`, `") + "`. " +
+					"Clusters are listed in the order returned by the Bonsai API if not set.",
+				Optional: true,
+			},
+			"sort_order": dschema.StringAttribute{
+				MarkdownDescription: "The order to sort the listed clusters in; either `" +
+					filter.SortOrderAscending + "` or `" + filter.SortOrderDescending + "`. " +
+					"Defaults to `" + filter.SortOrderAscending + "`.",
+				Optional: true,
+			},
 			"clusters": dschema.ListNestedAttribute{
 				MarkdownDescription: dataSourceMarkdownDescription,
 				Computed:            true,
@@ -46,14 +124,41 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 					Attributes: dataSourceSchemaAttributes(),
 				},
 			},
+			"slugs": dschema.ListAttribute{
+				MarkdownDescription: "The slugs of the listed clusters, in order.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"clusters_by_slug": dschema.MapNestedAttribute{
+				MarkdownDescription: "The listed clusters, keyed by their slugs, such as for use with `for_each`.",
+				Computed:            true,
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: dataSourceSchemaAttributes(),
+				},
+			},
 		},
 	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var state listDataSourceModel
 
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	match, diags := state.matcher()
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(filter.OneOf(state.SortBy, path.Root("sort_by"), filter.SortKeys(listSortKeys)...)...)
+	resp.Diagnostics.Append(filter.OneOf(state.SortOrder, path.Root("sort_order"), filter.SortOrderAscending, filter.SortOrderDescending)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	clusters, err := d.client.Cluster.All(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -63,32 +168,61 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 		return
 	}
 
+	clusters = slices.DeleteFunc(clusters, func(c bonsai.Cluster) bool { return !match(c) })
+	filter.Sort(clusters, state.SortBy, state.SortOrder, listSortKeys)
+
 	// Map response body to dataSourceModel
+	state.Clusters = make([]dataSourceModel, 0, len(clusters))
+	state.Slugs = make([]string, 0, len(clusters))
+	state.ClustersBySlug = make(map[string]dataSourceModel, len(clusters))
 	for _, c := range clusters {
 		clusterState := dataSourceConvert(c)
 		state.Clusters = append(state.Clusters, clusterState)
+		state.Slugs = append(state.Slugs, c.Slug)
+		state.ClustersBySlug[c.Slug] = clusterState
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
+// matcher returns a function reporting whether a Cluster matches the
+// data source's filter.
+func (m listDataSourceModel) matcher() (func(bonsai.Cluster) bool, diag.Diagnostics) {
+	if m.Filter == nil {
+		return func(bonsai.Cluster) bool { return true }, nil
+	}
+
+	f := *m.Filter
+
+	nameRegex, diags := filter.Regexp(f.NameRegex, path.Root("filter").AtName("name_regex"))
+
+	return func(c bonsai.Cluster) bool {
+		return (nameRegex == nil || nameRegex.MatchString(c.Name)) &&
+			filter.EqualFold(f.State, string(c.State)) &&
+			filter.Equal(f.PlanSlug, c.Plan.Slug) &&
+			filter.Equal(f.SpacePath, c.Space.Path) &&
+			filter.Equal(f.ReleaseSlug, c.Release.Slug) &&
+			filter.Equal(f.ServiceType, c.Release.ServiceType)
+	}, diags
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *listDataSource) Configure(_ context.Context, req tfds.ConfigureRequest, resp *tfds.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Cluster
+	d.client = &data.Client.Cluster
 }
@@ -0,0 +1,114 @@
+package cluster_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListDataSourceFilterTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestListDataSourceFilterTestSuite(t *testing.T) {
+	suite.Run(t, &ListDataSourceFilterTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *ListDataSourceFilterTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"clusters": [
+					{
+						"slug": "production-search-1234",
+						"name": "production-search",
+						"plan": {"slug": "standard-sm"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "production-logs-5678",
+						"name": "production-logs",
+						"plan": {"slug": "sandbox"},
+						"release": {"slug": "elasticsearch-7.10.2", "service_type": "elasticsearch"},
+						"space": {"path": "omc/bonsai/eu-west-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "staging-search-9012",
+						"name": "staging-search",
+						"plan": {"slug": "sandbox"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "DISABLED"
+					}
+				]
+			}
+		`))
+	})
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_NoFilter() {
+	resp := s.Read(cluster.NewListDataSource(), nil)
+
+	s.Equal([]string{"production-search-1234", "production-logs-5678", "staging-search-9012"}, s.Strings(resp, path.Root("slugs")))
+
+	s.Equal("staging-search", s.String(resp, path.Root("clusters_by_slug").AtMapKey("staging-search-9012").AtName("name")))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_Filter() {
+	s.Equal([]string{"production-search-1234", "production-logs-5678"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"filter.name_regex": "^production-",
+	}), path.Root("slugs")))
+
+	s.Equal([]string{"staging-search-9012"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"filter.state": "disabled",
+	}), path.Root("slugs")))
+
+	s.Equal([]string{"production-search-1234"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"filter.service_type": "opensearch",
+		"filter.plan_slug":    "standard-sm",
+	}), path.Root("slugs")))
+
+	s.Equal([]string{"production-logs-5678"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"filter.space_path":   "omc/bonsai/eu-west-1/common",
+		"filter.release_slug": "elasticsearch-7.10.2",
+	}), path.Root("slugs")))
+
+	s.Empty(s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"filter.plan_slug": "enterprise-xl",
+	}), path.Root("slugs")))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_Sort() {
+	s.Equal([]string{"production-logs-5678", "production-search-1234", "staging-search-9012"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"sort_by": "name",
+	}), path.Root("slugs")))
+
+	s.Equal([]string{"staging-search-9012", "production-search-1234", "production-logs-5678"}, s.Strings(s.Read(cluster.NewListDataSource(), map[string]any{
+		"sort_by":    "slug",
+		"sort_order": "desc",
+	}), path.Root("slugs")))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_InvalidArguments() {
+	resp := s.Read(cluster.NewListDataSource(), map[string]any{"filter.name_regex": "("})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Invalid Regular Expression", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(cluster.NewListDataSource(), map[string]any{"sort_by": "price"})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), `got "price"`)
+
+	resp = s.Read(cluster.NewListDataSource(), map[string]any{"sort_order": "up"})
+	s.True(resp.Diagnostics.HasError())
+}
@@ -0,0 +1,148 @@
+package cluster_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type DataSourceLookupTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestDataSourceLookupTestSuite(t *testing.T) {
+	suite.Run(t, &DataSourceLookupTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *DataSourceLookupTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"clusters": [
+					{
+						"slug": "search-1234",
+						"name": "search",
+						"plan": {"slug": "standard-sm"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "search-5678",
+						"name": "search",
+						"plan": {"slug": "standard-sm"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/eu-west-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "logs-9012",
+						"name": "logs",
+						"plan": {"slug": "sandbox"},
+						"release": {"slug": "elasticsearch-7.10.2", "service_type": "elasticsearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "logs-3456",
+						"name": "logs",
+						"plan": {"slug": "sandbox"},
+						"release": {"slug": "elasticsearch-7.10.2", "service_type": "elasticsearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "DEPROVISIONED"
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath+"/{slug}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"cluster": {
+					"slug": "search-1234",
+					"name": "search",
+					"plan": {"slug": "standard-sm"},
+					"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+					"space": {"path": "omc/bonsai/us-east-1/common"},
+					"state": "PROVISIONED"
+				}
+			}
+		`))
+	})
+}
+
+func (s *DataSourceLookupTestSuite) TestDataSource_LookupBySlug() {
+	s.Equal("search-1234", s.String(s.Read(cluster.NewDataSource(), map[string]any{"slug": "search-1234"}), path.Root("slug")))
+}
+
+func (s *DataSourceLookupTestSuite) TestDataSource_LookupByName() {
+	// Deprovisioned clusters are ignored.
+	s.Equal("logs-9012", s.String(s.Read(cluster.NewDataSource(), map[string]any{"name": "logs"}), path.Root("slug")))
+
+	resp := s.Read(cluster.NewDataSource(), map[string]any{
+		"name":       "search",
+		"space_path": "omc/bonsai/eu-west-1/common",
+	})
+	s.Equal("search-5678", s.String(resp, path.Root("slug")))
+
+	s.Equal("omc/bonsai/eu-west-1/common", s.String(resp, path.Root("space").AtName("path")))
+
+	s.Equal("logs-9012", s.String(s.Read(cluster.NewDataSource(), map[string]any{"name_regex": "^lo"}), path.Root("slug")))
+}
+
+func (s *DataSourceLookupTestSuite) TestDataSource_LookupMatchesSeveral() {
+	resp := s.Read(cluster.NewDataSource(), map[string]any{"name": "search"})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Multiple Bonsai Clusters Match the Lookup", resp.Diagnostics.Errors()[0].Summary())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "search-1234")
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "search-5678")
+
+	resp = s.Read(cluster.NewDataSource(), map[string]any{
+		"name_regex": ".",
+		"space_path": "omc/bonsai/us-east-1/common",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "2 clusters")
+}
+
+func (s *DataSourceLookupTestSuite) TestDataSource_LookupMatchesNone() {
+	resp := s.Read(cluster.NewDataSource(), map[string]any{
+		"name":       "logs",
+		"space_path": "omc/bonsai/eu-west-1/common",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("No Bonsai Cluster Matches the Lookup", resp.Diagnostics.Errors()[0].Summary())
+
+	// The candidates in the space are listed.
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "search-5678")
+	s.NotContains(resp.Diagnostics.Errors()[0].Detail(), "logs-9012")
+}
+
+func (s *DataSourceLookupTestSuite) TestDataSource_LookupErrors() {
+	for _, arguments := range []map[string]any{
+		{"slug": "search-1234", "name": "search"},
+		{"name": "search", "name_regex": "^search$"},
+	} {
+		resp := s.Read(cluster.NewDataSource(), arguments)
+		s.True(resp.Diagnostics.HasError())
+		s.Equal("Conflicting Bonsai Cluster Lookup", resp.Diagnostics.Errors()[0].Summary())
+	}
+
+	resp := s.Read(cluster.NewDataSource(), map[string]any{"name_regex": "("})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Invalid Regular Expression", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(cluster.NewDataSource(), map[string]any{"space_path": "omc/bonsai/us-east-1/common"})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "expected 'slug', 'name' or 'name_regex'")
+}
@@ -38,12 +38,18 @@ func (s *ClusterTestSuite) TestCluster_DataSource() {
 						  slug = resource.bonsai_cluster.test.id
 						}
 
+						data "bonsai_cluster" "get_by_name" {
+						  name       = resource.bonsai_cluster.test.name
+						  space_path = "omc/bonsai/us-east-1/common"
+						}
+
 						output "bonsai_cluster_slug" {
 						  value = data.bonsai_cluster.get_by_slug.slug
 						}
 					`, clusterName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.bonsai_cluster.get_by_slug", "slug"),
+					resource.TestCheckResourceAttrPair("data.bonsai_cluster.get_by_name", "slug", "bonsai_cluster.test", "slug"),
 				),
 			},
 		},
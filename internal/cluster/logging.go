@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+)
+
+// clusterLogFields returns the non-sensitive details of a Cluster, for
+// structured logging. Credentials are never included.
+func clusterLogFields(c bonsai.Cluster) map[string]interface{} {
+	return map[string]interface{}{
+		"slug":         c.Slug,
+		"name":         c.Name,
+		"state":        string(c.State),
+		"plan":         c.Plan.Slug,
+		"space":        c.Space.Path,
+		"release":      c.Release.Slug,
+		"access_host":  c.Access.Host,
+		"access_url":   logging.RedactURL(c.Access.URL),
+		"stats_docs":   c.Stats.Docs,
+		"stats_shards": c.Stats.ShardsUsed,
+	}
+}
+
+// createResultLogFields returns the non-sensitive details of a Cluster
+// creation result, for structured logging. Credentials are never included.
+func createResultLogFields(c bonsai.ClustersResultCreate) map[string]interface{} {
+	return map[string]interface{}{
+		"message":     c.Message,
+		"monitor":     c.Monitor,
+		"access_host": c.Access.Host,
+		"access_url":  logging.RedactURL(c.Access.URL),
+	}
+}
+
+// modelLogFields returns the non-sensitive details of a resourceModel, for
+// structured logging. Credentials are never included.
+func modelLogFields(m resourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      m.ID.ValueString(),
+		"slug":    m.Slug.ValueString(),
+		"name":    m.Name.ValueString(),
+		"plan":    m.Plan.Slug.ValueString(),
+		"space":   m.Space.Path.ValueString(),
+		"release": m.Release.Slug.ValueString(),
+	}
+}
+
+// maskAccessSecrets configures ctx's logger to mask the credentials held in
+// a Cluster's access object, if any.
+func maskAccessSecrets(ctx context.Context, access types.Object) context.Context {
+	if access.IsNull() || access.IsUnknown() {
+		return ctx
+	}
+
+	m := accessModel{}
+	if diags := access.As(ctx, &m, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return ctx
+	}
+
+	return logging.MaskSecrets(ctx, m.Username.ValueString(), m.Password.ValueString())
+}
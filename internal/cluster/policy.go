@@ -0,0 +1,205 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+)
+
+// policyAttributePaths locates the attributes restricted by a policy.
+var policyAttributePaths = map[string]path.Path{
+	policy.AttributePlan:    path.Root("plan").AtName("slug"),
+	policy.AttributeSpace:   path.Root("space").AtName("path"),
+	policy.AttributeRelease: path.Root("release").AtName("slug"),
+}
+
+// validatePolicy checks the planned Cluster against the provider's allowed
+// plans, spaces and releases.
+//
+// Only attributes which changed from the prior state are checked, such that
+// existing Clusters may still be managed after the policy is tightened.
+// Attributes are read from the plan by path, as a restricted attribute which
+// is omitted, or which isn't yet known, is refused rather than skipped.
+func (r *resource) validatePolicy(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.policy.IsZero() {
+		return diags
+	}
+
+	values := map[string]string{}
+	for _, attribute := range []string{policy.AttributePlan, policy.AttributeSpace, policy.AttributeRelease} {
+		if !r.policy.Restricts(attribute) {
+			continue
+		}
+
+		p := policyAttributePaths[attribute]
+
+		var (
+			desired, prior types.String
+			getDiags       diag.Diagnostics
+		)
+		getDiags.Append(plan.GetAttribute(ctx, p, &desired)...)
+		if !state.Raw.IsNull() {
+			getDiags.Append(state.GetAttribute(ctx, p, &prior)...)
+		}
+		diags.Append(getDiags...)
+		if getDiags.HasError() {
+			return diags
+		}
+
+		if !state.Raw.IsNull() && desired.Equal(prior) {
+			continue
+		}
+
+		if !knownString(desired) {
+			diags.AddAttributeError(
+				p,
+				"Bonsai Cluster Policy Violation",
+				fmt.Sprintf(
+					"The %s must be known when the cluster is planned, as it's restricted by the provider's allowed_%ss policy. Allowed %ss: %s.\n\n"+
+						"Set a permitted %s in the configuration.",
+					attribute,
+					attribute,
+					attribute,
+					formatAlternatives(r.policy.Allowed(attribute)),
+					attribute,
+				),
+			)
+			continue
+		}
+
+		values[attribute] = desired.ValueString()
+	}
+
+	opts := bonsai.ClusterCreateOpts{
+		Plan:    values[policy.AttributePlan],
+		Space:   values[policy.AttributeSpace],
+		Release: values[policy.AttributeRelease],
+	}
+
+	for _, v := range r.policy.Check(opts) {
+		diags.AddAttributeError(
+			policyAttributePaths[v.Attribute],
+			"Bonsai Cluster Policy Violation",
+			fmt.Sprintf(
+				"The %s %q is not permitted by the provider's allowed_%ss policy. Allowed %ss: %s.\n\n"+
+					"Choose a permitted %s, or ask the maintainers of the provider configuration to permit it.",
+				v.Attribute,
+				v.Value,
+				v.Attribute,
+				v.Attribute,
+				formatAlternatives(v.Allowed),
+				v.Attribute,
+			),
+		)
+	}
+
+	return diags
+}
+
+// validateBudget records the desired Cluster's Plan against the provider's
+// monthly spend budget, if any, reporting whether the running total of the
+// planned monthly spend exceeds it.
+//
+// A null desired Cluster is planned to be destroyed, while replace reports
+// whether the prior Cluster is planned to be replaced.
+func (r *resource) validateBudget(ctx context.Context, desired *resourceModel, prior *resourceModel, replace bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.budget == nil {
+		return diags
+	}
+
+	change := budget.Change{Replace: replace}
+	if prior != nil {
+		change.Slug = prior.Slug.ValueString()
+	}
+
+	if desired != nil {
+		// The cost of a Plan which isn't yet known can't be estimated.
+		if !knownString(desired.Plan.Slug) {
+			return diags
+		}
+		change.PlanSlug = desired.Plan.Slug.ValueString()
+		change.Name = desired.Name.ValueString()
+		change.SpacePath = desired.Space.Path.ValueString()
+		change.ReleaseSlug = desired.Release.Slug.ValueString()
+	}
+
+	spend, over, err := r.budget.Plan(ctx, change)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Check Bonsai Monthly Spend Budget",
+			"The planned monthly spend couldn't be estimated, so the provider's "+
+				"max_monthly_spend_cents budget was not checked.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Estimated planned monthly spend", map[string]interface{}{
+		"cluster_cents":      spend.Cluster,
+		"current_cents":      spend.Current,
+		"planned_cents":      spend.Planned,
+		"planned_clusters":   spend.PlannedClusters,
+		"unmanaged_cents":    spend.Unmanaged,
+		"unmanaged_clusters": spend.UnmanagedClusters,
+		"budget_cents":       r.budget.MaxMonthlySpendCents,
+	})
+
+	if !over {
+		return diags
+	}
+
+	summary := "Bonsai Monthly Spend Budget Exceeded"
+	detail := fmt.Sprintf(
+		"The planned monthly spend of %s exceeds the provider's max_monthly_spend_cents budget of %s.\n\n"+
+			"This includes %s for this cluster's plan, up from %s currently, "+
+			"%s across %d other cluster(s) planned by this configuration so far, "+
+			"and %s across %d other cluster(s) in the account, at their current plans.",
+		budget.FormatCents(spend.Total()),
+		budget.FormatCents(r.budget.MaxMonthlySpendCents),
+		budget.FormatCents(spend.Cluster),
+		budget.FormatCents(spend.Current),
+		budget.FormatCents(spend.Planned),
+		spend.PlannedClusters,
+		budget.FormatCents(spend.Unmanaged),
+		spend.UnmanagedClusters,
+	)
+
+	if r.budget.WarnOnly {
+		diags.AddAttributeWarning(path.Root("plan").AtName("slug"), summary, detail)
+	} else {
+		diags.AddAttributeError(path.Root("plan").AtName("slug"), summary, detail)
+	}
+
+	return diags
+}
+
+// checkWritable reports an error if the provider is read-only, such that
+// the operation fails before any request is made to the Bonsai API.
+func (r *resource) checkWritable(operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.readOnly {
+		diags.AddError(
+			"Bonsai Provider is Read-Only",
+			fmt.Sprintf(
+				"Unable to %s the cluster, as the provider is configured with read_only = true. "+
+					"Unset read_only, or set it to false, to allow clusters to be created, updated and deleted.",
+				operation,
+			),
+		)
+	}
+
+	return diags
+}
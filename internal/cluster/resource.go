@@ -8,21 +8,31 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfrsc "github.com/hashicorp/terraform-plugin-framework/resource"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ tfrsc.Resource              = &resource{}
-	_ tfrsc.ResourceWithConfigure = &resource{}
+	_ tfrsc.Resource                = &resource{}
+	_ tfrsc.ResourceWithConfigure   = &resource{}
+	_ tfrsc.ResourceWithImportState = &resource{}
+	_ tfrsc.ResourceWithModifyPlan  = &resource{}
 
 	// Unavailable Regexp matches fields which are returned as not available
 	// during cluster provisioning.
@@ -33,6 +43,14 @@ var (
 	updateRequestProcessingRegexp = regexp.MustCompile(`Your cluster is being updated`)
 )
 
+// Default durations to await Cluster operations, overridable by
+// practitioners through the timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
 // resourceModel maps clusters schema data.
 type resourceModel struct {
 	// ID is a unique identifier, only set for terraform's management.
@@ -54,11 +72,39 @@ type resourceModel struct {
 	Stats   types.Object `tfsdk:"stats"`
 	Access  types.Object `tfsdk:"access"`
 	State   types.Object `tfsdk:"state"`
+
+	// DeletionProtection and ForceDestroy only exist within Terraform,
+	// guarding against the accidental destruction of a Cluster.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// retainConfiguration copies the attributes which only exist within
+// Terraform, and so aren't returned by the Bonsai API, from src to dst.
+func retainConfiguration(dst *resourceModel, src resourceModel) {
+	dst.Timeouts = src.Timeouts
+
+	dst.DeletionProtection = src.DeletionProtection
+	if dst.DeletionProtection.IsNull() {
+		dst.DeletionProtection = types.BoolValue(false)
+	}
+
+	dst.ForceDestroy = src.ForceDestroy
+	if dst.ForceDestroy.IsNull() {
+		dst.ForceDestroy = types.BoolValue(false)
+	}
 }
 
 // dataSource is the data source implementation.
 type resource struct {
-	client *bonsai.ClusterClient
+	client  *bonsai.ClusterClient
+	catalog *catalog.Catalog
+	policy  policy.Policy
+	budget  *budget.Budget
+	// readOnly refuses to create, update or delete Clusters.
+	readOnly bool
 }
 
 // NewResource is a helper function to simplify the provider implementation.
@@ -76,17 +122,21 @@ func (r *resource) Configure(_ context.Context, req tfrsc.ConfigureRequest, resp
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected resource Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = &client.Cluster
+	r.client = &data.Client.Cluster
+	r.catalog = data.Catalog
+	r.policy = data.Policy
+	r.budget = data.Budget
+	r.readOnly = data.ReadOnly
 }
 
 func resourceSchemaAttributes() map[string]rschema.Attribute {
@@ -151,8 +201,15 @@ func resourceSchemaAttributes() map[string]rschema.Attribute {
 					Computed:            true,
 				},
 				"slug": rschema.StringAttribute{
-					MarkdownDescription: "The machine-readable name for the deployment.",
-					Optional:            true,
+					MarkdownDescription: "The machine-readable name for the deployment. " +
+						"Changing the release requires the cluster to be replaced, " +
+						"while removing it from configuration keeps the current release.",
+					Optional: true,
+					Computed: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+						requiresReplaceIfConfigured("release.slug"),
+					},
 				},
 				"uri": rschema.StringAttribute{
 					MarkdownDescription: "A URI to retrieve more information about this Release.",
@@ -165,8 +222,15 @@ func resourceSchemaAttributes() map[string]rschema.Attribute {
 			MarkdownDescription: "Space holds some information about where the cluster is running.",
 			Attributes: map[string]rschema.Attribute{
 				"path": rschema.StringAttribute{
-					MarkdownDescription: "A machine-readable name for the server group.",
-					Optional:            true,
+					MarkdownDescription: "A machine-readable name for the server group. " +
+						"Changing the space requires the cluster to be replaced, " +
+						"while removing it from configuration keeps the current space.",
+					Optional: true,
+					Computed: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+						requiresReplaceIfConfigured("space.path"),
+					},
 				},
 				"region": rschema.StringAttribute{
 					MarkdownDescription: "The geographic region in which the cluster is running.",
@@ -244,6 +308,22 @@ func resourceSchemaAttributes() map[string]rschema.Attribute {
 				},
 			},
 		},
+		"deletion_protection": rschema.BoolAttribute{
+			MarkdownDescription: "Whether the cluster is protected from " +
+				"deletion. While `true`, destroying the cluster will fail. " +
+				"Default: `false`.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
+		"force_destroy": rschema.BoolAttribute{
+			MarkdownDescription: "Whether the cluster may be destroyed while " +
+				"it still holds documents. While `false`, destroying a cluster " +
+				"with a non-zero `stats.docs` will fail. Default: `false`.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
 		"state": rschema.SingleNestedAttribute{
 			MarkdownDescription: "State represents the current state of the " +
 				"cluster. This indicates what the cluster is doing at " +
@@ -259,6 +339,43 @@ func resourceSchemaAttributes() map[string]rschema.Attribute {
 	}
 }
 
+// requiresReplaceIfConfigured forces the replacement of a Cluster when the
+// attribute, which can't be updated in place through the Bonsai API, is
+// changed in configuration. A warning explains the replacement in the plan.
+func requiresReplaceIfConfigured(attribute string) planmodifier.String {
+	description := fmt.Sprintf(
+		"The Bonsai API can't change a cluster's %s in place; changing it "+
+			"replaces the cluster.",
+		attribute,
+	)
+
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			// Removing the attribute from configuration keeps the cluster as-is,
+			// as UseStateForUnknown plans its current value.
+			if req.ConfigValue.IsNull() {
+				return
+			}
+
+			resp.RequiresReplace = true
+			resp.Diagnostics.AddAttributeWarning(
+				req.Path,
+				"Bonsai Cluster Will Be Replaced",
+				fmt.Sprintf(
+					"%s Changing %s from %s to %s will destroy the existing "+
+						"cluster, including all of its data, and create a new one.",
+					description,
+					attribute,
+					req.StateValue,
+					req.PlanValue,
+				),
+			)
+		},
+		description,
+		description,
+	)
+}
+
 func convertResourceClusterToCreateRequest(r resourceModel) bonsai.ClusterCreateOpts {
 	return bonsai.ClusterCreateOpts{
 		Name:    r.Name.ValueString(),
@@ -315,6 +432,26 @@ func convertCreateResponseModelToResourceCluster(c bonsai.ClustersResultCreate)
 	return m, nil
 }
 
+// convertAccessWithoutCredentials converts the connection details of a
+// Cluster's access, leaving the credentials (which the API only returns
+// during Cluster.Create) as null.
+func convertAccessWithoutCredentials(a bonsai.ClusterAccess) (types.Object, error) {
+	access, diags := types.ObjectValueFrom(context.TODO(), accessModelTypes, &accessModel{
+		Host:     types.StringValue(a.Host),
+		Port:     types.Int64Value(int64(a.Port)),
+		Scheme:   types.StringValue(a.Scheme),
+		Username: types.StringNull(),
+		Password: types.StringNull(),
+		URL:      types.StringNull(),
+	})
+	if diags.HasError() {
+		return types.ObjectNull(accessModelTypes),
+			fmt.Errorf("error reading cluster access: %s - %s", diags[0].Summary(), diags[0].Detail())
+	}
+
+	return access, nil
+}
+
 func resourceConvert(c bonsai.Cluster) (resourceModel, error) {
 	access, diags := types.ObjectValueFrom(context.TODO(), accessModelTypes, &accessModel{
 		Host:     types.StringValue(c.Access.Host),
@@ -374,23 +511,96 @@ func resourceConvert(c bonsai.Cluster) (resourceModel, error) {
 	return m, nil
 }
 
+func resourceSchemaBlocks(ctx context.Context) map[string]rschema.Block {
+	return map[string]rschema.Block{
+		"timeouts": timeouts.Block(ctx, timeouts.Opts{
+			Create: true,
+			CreateDescription: fmt.Sprintf(
+				"How long to await the cluster's provisioning, as a duration "+
+					"string such as \"30s\" or \"2h45m\". Default: \"%s\".",
+				defaultCreateTimeout,
+			),
+			Update: true,
+			UpdateDescription: fmt.Sprintf(
+				"How long to await the cluster's update, as a duration "+
+					"string such as \"30s\" or \"2h45m\". Default: \"%s\".",
+				defaultUpdateTimeout,
+			),
+			Delete: true,
+			DeleteDescription: fmt.Sprintf(
+				"How long to await the cluster's deprovisioning, as a duration "+
+					"string such as \"30s\" or \"2h45m\". Default: \"%s\".",
+				defaultDeleteTimeout,
+			),
+		}),
+	}
+}
+
 // Schema returns the schema information for a cluster create request resource.
-func (r *resource) Schema(_ context.Context, _ tfrsc.SchemaRequest, resp *tfrsc.SchemaResponse) {
+func (r *resource) Schema(ctx context.Context, _ tfrsc.SchemaRequest, resp *tfrsc.SchemaResponse) {
 	resp.Schema = rschema.Schema{
 		MarkdownDescription: resourceMarkdownDescription,
 		Attributes:          resourceSchemaAttributes(),
+		Blocks:              resourceSchemaBlocks(ctx),
+	}
+}
+
+// ModifyPlan validates the planned combination of Plan, Space and Release
+// against the Bonsai catalog, so that incompatible combinations are
+// reported during planning rather than failing during apply.
+func (r *resource) ModifyPlan(ctx context.Context, req tfrsc.ModifyPlanRequest, resp *tfrsc.ModifyPlanResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
+	var desired resourceModel
+
+	// Nothing to validate before the provider is configured.
+	if r.client == nil {
+		return
+	}
+
+	var prior *resourceModel
+	if !req.State.Raw.IsNull() {
+		prior = &resourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// While destroying, only the budget need learn of the Cluster's removal.
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(r.validateBudget(ctx, nil, prior, false)...)
+		return
+	}
+
+	resp.Diagnostics.Append(r.validatePolicy(ctx, req.Plan, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &desired)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	resp.Diagnostics.Append(r.validateBudget(ctx, &desired, prior, len(resp.RequiresReplace) > 0)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateCompatibility(ctx, desired, prior)...)
 }
 
 // Create requests a new Cluster to be created.
 func (r *resource) Create(ctx context.Context, req tfrsc.CreateRequest, resp *tfrsc.CreateResponse) {
-	var (
-		state, createResultState, refreshState resourceModel
-		refreshResult                          bonsai.Cluster
-	)
+	ctx = logging.MaskCredentials(ctx)
 
-	refreshDeadline := 5 * time.Minute
-	refreshDelay := 10 * time.Second
+	resp.Diagnostics.Append(r.checkWritable("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state, createResultState, refreshState resourceModel
 
 	diags := req.Plan.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -398,25 +608,38 @@ func (r *resource) Create(ctx context.Context, req tfrsc.CreateRequest, resp *tf
 		return
 	}
 
+	refreshDeadline, diags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createRequest := convertResourceClusterToCreateRequest(state)
-	tflog.Debug(ctx, fmt.Sprintf("create request: %+v", createRequest))
+	tflog.Debug(ctx, "create request", map[string]interface{}{
+		"name":    createRequest.Name,
+		"plan":    createRequest.Plan,
+		"space":   createRequest.Space,
+		"release": createRequest.Release,
+	})
 
 	createResult, err := r.client.Cluster.Create(ctx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Create Bonsai Cluster (%v) from the Bonsai API", state),
+			fmt.Sprintf("Unable to Create Bonsai Cluster (%s) from the Bonsai API", state.Name.ValueString()),
 			err.Error(),
 		)
 		tflog.Debug(ctx, fmt.Sprintf("returning error %s", err.Error()))
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("received cluster %+v", createResult))
+	// Credentials are only received here; ensure they're never logged.
+	ctx = logging.MaskSecrets(ctx, createResult.Access.Username, createResult.Access.Password)
+	tflog.Debug(ctx, "received cluster", createResultLogFields(createResult))
 
 	// Handles null nested objects as well
 	createResultState, err = convertCreateResponseModelToResourceCluster(createResult)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("failed to convert response to resource Cluster (%v) from the Bonsai API", createResult),
+			fmt.Sprintf("failed to convert response to resource Cluster (%s) from the Bonsai API", createResult.Access.Host),
 			err.Error(),
 		)
 		return
@@ -434,71 +657,49 @@ func (r *resource) Create(ctx context.Context, req tfrsc.CreateRequest, resp *tf
 	createResultState.Plan.Slug = state.Plan.Slug
 	createResultState.Space.Path = state.Space.Path
 	createResultState.Release.Slug = state.Release.Slug
+	// Unless configured, the space and release are chosen by the API, and
+	// aren't known until the cluster is refreshed.
+	if createResultState.Space.Path.IsUnknown() {
+		createResultState.Space.Path = types.StringNull()
+	}
+	if createResultState.Release.Slug.IsUnknown() {
+		createResultState.Release.Slug = types.StringNull()
+	}
+	retainConfiguration(&createResultState, state)
 	// And, set the unique identifier
 	createResultState.ID = createResultState.Slug
 
-	refreshCtx, refreshCancel := context.WithDeadline(ctx, time.Now().Add(refreshDeadline))
-	defer refreshCancel()
-
-DiscoveryLoop:
-	for {
-		select {
-		case <-refreshCtx.Done():
-			// On the event of time-out, set the state we *do* know.
-			diags = resp.State.Set(ctx, createResultState)
-			tflog.Debug(ctx, fmt.Sprintf("context done - set cluster state %+v", createResultState))
-			resp.Diagnostics.Append(diags...)
-			tflog.Debug(ctx, fmt.Sprintf("context done - appended diags %+v", createResultState))
-
-			resp.Diagnostics.AddError(
-				fmt.Sprintf(
-					"Timed out while awaiting Bonsai Cluster (%s) provision, in time (%d)",
-					state.Slug.ValueString(),
-					refreshDeadline,
-				),
-				ctx.Err().Error(),
-			)
-			return
-		default:
-			refreshResult, err = r.client.Cluster.GetBySlug(ctx, createResultState.Slug.ValueString())
-			// If we encounter an error, and it's not just that the cluster
-			// hasn't been created yet...
-			if err != nil {
-				if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
-					// Sleep for a little bit; all of these should be refactored at some point
-					time.Sleep(refreshDelay)
-					continue DiscoveryLoop
-				}
-
-				resp.Diagnostics.AddError(
-					"Error while fetching new Bonsai Cluster state",
-					fmt.Sprintf(
-						"Failed while refreshing Cluster (%s) state after create, unexpected error: %s",
-						createResultState.Slug.ValueString(),
-						err,
-					),
-				)
-				return
-			}
-
-			if unavailableRegexp.MatchString(refreshResult.Space.Path) || unavailableRegexp.MatchString(refreshResult.Space.URI) {
-				continue DiscoveryLoop
-			}
+	refreshResult, err := newCreateWaiter(
+		r.client,
+		createResultState.Slug.ValueString(),
+		refreshDeadline,
+	).Wait(ctx)
+	if err != nil {
+		// In the event of a failed wait, set the state we *do* know.
+		diags = resp.State.Set(ctx, createResultState)
+		resp.Diagnostics.Append(diags...)
+		tflog.Debug(ctx, "wait failed - set cluster state", modelLogFields(createResultState))
 
-			// We received a good result
-			break DiscoveryLoop
-		}
+		resp.Diagnostics.AddError(
+			waitErrorSummary("provision", createResultState.Slug.ValueString(), err),
+			fmt.Sprintf(
+				"Failed while refreshing Cluster (%s) state after create: %s",
+				createResultState.Slug.ValueString(),
+				err,
+			),
+		)
+		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("received refreshed cluster: %+v", refreshResult))
-	tflog.Debug(ctx, fmt.Sprintf("created cluster %+v", createResultState))
+	tflog.Debug(ctx, "received refreshed cluster", clusterLogFields(refreshResult))
+	tflog.Debug(ctx, "created cluster", modelLogFields(createResultState))
 
 	refreshState, err = resourceConvert(refreshResult)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf(
-				"failed to convert refresh response (%v) to resource Cluster from the Bonsai API",
-				refreshResult,
+				"failed to convert refresh response (%s) to resource Cluster from the Bonsai API",
+				refreshResult.Slug,
 			),
 			err.Error(),
 		)
@@ -534,10 +735,11 @@ DiscoveryLoop:
 
 	// And, set the unique identifier
 	refreshState.ID = createResultState.ID
+	retainConfiguration(&refreshState, state)
 
 	diags = resp.State.Set(ctx, refreshState)
 
-	tflog.Debug(ctx, fmt.Sprintf("set cluster state %+v", createResultState))
+	tflog.Debug(ctx, "set cluster state", modelLogFields(refreshState))
 	resp.Diagnostics.Append(diags...)
 	tflog.Debug(ctx, "appended diags after cluster state set")
 	if resp.Diagnostics.HasError() {
@@ -548,6 +750,8 @@ DiscoveryLoop:
 // Read is called when the provider must read resource values in order
 // to update state.
 func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var (
 		state, apiState resourceModel
 	)
@@ -558,6 +762,8 @@ func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.
 		return
 	}
 
+	ctx = maskAccessSecrets(ctx, state.Access)
+
 	if state.Slug.IsNull() {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
@@ -567,8 +773,15 @@ func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.
 	}
 
 	apiResp, err := r.client.Cluster.GetBySlug(ctx, state.ID.ValueString())
-	tflog.Debug(ctx, fmt.Sprintf("received cluster %v", apiResp))
+	tflog.Debug(ctx, "received cluster", clusterLogFields(apiResp))
 	if err != nil {
+		// The cluster was deleted outside of Terraform; remove it from state
+		// so that it's planned for re-creation.
+		if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+			removeMissingResource(ctx, resp, state.ID.ValueString(), "could not be found")
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
 			err.Error(),
@@ -576,10 +789,15 @@ func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.
 		return
 	}
 
+	if apiResp.State == bonsai.ClusterStateDeprovisioned {
+		removeMissingResource(ctx, resp, state.ID.ValueString(), "has been deprovisioned")
+		return
+	}
+
 	apiState, err = resourceConvert(apiResp)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("failed to convert response to resource Cluster (%+v)", apiResp),
+			fmt.Sprintf("failed to convert response to resource Cluster (%s)", apiResp.Slug),
 			err.Error(),
 		)
 		return
@@ -587,12 +805,28 @@ func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.
 
 	// Set state details
 	apiState.ID = state.ID
-	apiState.Access = state.Access
+	retainConfiguration(&apiState, state)
+
+	// Credentials are only returned during Cluster.Create, so retain
+	// whatever access details we already hold. Imported clusters won't
+	// have any, so fall back to the connection details without credentials.
+	if state.Access.IsNull() {
+		apiState.Access, err = convertAccessWithoutCredentials(apiResp.Access)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("failed to convert response access for resource Cluster (%s)", apiResp.Slug),
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		apiState.Access = state.Access
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("read state %v", apiState))
+	tflog.Debug(ctx, "read state", modelLogFields(apiState))
 
 	diags = resp.State.Set(ctx, apiState)
-	tflog.Debug(ctx, fmt.Sprintf("setting Read api state %v", apiState))
+	tflog.Debug(ctx, "setting Read api state", modelLogFields(apiState))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -600,16 +834,33 @@ func (r *resource) Read(ctx context.Context, req tfrsc.ReadRequest, resp *tfrsc.
 	tflog.Debug(ctx, "returning from read")
 }
 
+// removeMissingResource removes a Cluster which no longer exists from the
+// Terraform state, warning the practitioner of the drift.
+func removeMissingResource(ctx context.Context, resp *tfrsc.ReadResponse, slug, reason string) {
+	tflog.Warn(ctx, "removing cluster from state", map[string]interface{}{
+		"slug":   slug,
+		"reason": reason,
+	})
+
+	resp.State.RemoveResource(ctx)
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Bonsai Cluster (%s) %s", slug, reason),
+		"The cluster appears to have been deleted outside of Terraform, and "+
+			"has been removed from the Terraform state. If it's still part of "+
+			"the configuration, it will be planned for creation.",
+	)
+}
+
 // Update updates the Alias state.
 func (r *resource) Update(ctx context.Context, req tfrsc.UpdateRequest, resp *tfrsc.UpdateResponse) {
-	var (
-		refreshResult                bonsai.Cluster
-		desired, state, refreshState resourceModel
-		err                          error
-	)
+	ctx = logging.MaskCredentials(ctx)
 
-	refreshDeadline := 5 * time.Minute
-	refreshDelay := 10 * time.Second
+	resp.Diagnostics.Append(r.checkWritable("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired, state, refreshState resourceModel
 
 	diags := req.Plan.Get(ctx, &desired)
 	resp.Diagnostics.Append(diags...)
@@ -617,11 +868,27 @@ func (r *resource) Update(ctx context.Context, req tfrsc.UpdateRequest, resp *tf
 		return
 	}
 
+	refreshDeadline, diags := desired.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = maskAccessSecrets(ctx, state.Access)
+
+	// Only attributes which exist within Terraform have changed, so there's
+	// nothing to request of the Bonsai API.
+	if desired.Name.Equal(state.Name) && desired.Plan.Slug.Equal(state.Plan.Slug) {
+		retainConfiguration(&state, desired)
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
 
 	updateOpts := bonsai.ClusterUpdateOpts{
 		Name: desired.Name.ValueString(),
@@ -629,7 +896,10 @@ func (r *resource) Update(ctx context.Context, req tfrsc.UpdateRequest, resp *tf
 	}
 
 	updateResp, err := r.client.Cluster.Update(ctx, state.Slug.ValueString(), updateOpts)
-	tflog.Debug(ctx, fmt.Sprintf("received cluster update response: %v", updateResp))
+	tflog.Debug(ctx, "received cluster update response", map[string]interface{}{
+		"message": updateResp.Message,
+		"monitor": updateResp.Monitor,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf(
@@ -655,56 +925,31 @@ func (r *resource) Update(ctx context.Context, req tfrsc.UpdateRequest, resp *tf
 		return
 	}
 
-	refreshCtx, refreshCancel := context.WithDeadline(ctx, time.Now().Add(refreshDeadline))
-	defer refreshCancel()
-
-UpdateLoop:
-	for {
-		select {
-		case <-refreshCtx.Done():
-			resp.Diagnostics.AddError(
-				fmt.Sprintf(
-					"Update timed out while updating Bonsai Cluster (%s) with desired state (%v), in time (%d)",
-					state.Slug.ValueString(),
-					updateOpts,
-					refreshDeadline,
-				),
-				ctx.Err().Error(),
-			)
-			return
-		default:
-			// Sleep for a little bit; all of these should be refactored at some point
-			time.Sleep(refreshDelay)
-			refreshResult, err = r.client.Cluster.GetBySlug(ctx, state.Slug.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Update: Error refreshing Bonsai Cluster state",
-					fmt.Sprintf(
-						"Failed while refreshing Cluster (%s) state after update, unexpected error: %s",
-						state.Slug.ValueString(),
-						err,
-					),
-				)
-				return
-			}
-
-			// If we're not updating the plan, and we've got the desired name,
-			// we're done!
-			if refreshResult.State != bonsai.ClusterStateUpdatingPlan &&
-				refreshResult.Name == desired.Name.ValueString() {
-				break UpdateLoop
-			}
-
-			continue UpdateLoop
-		}
+	refreshResult, err := newUpdateWaiter(
+		r.client,
+		state.Slug.ValueString(),
+		desired,
+		refreshDeadline,
+	).Wait(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			waitErrorSummary("update", state.Slug.ValueString(), err),
+			fmt.Sprintf(
+				"Failed while refreshing Cluster (%s) state after update with desired state (%v): %s",
+				state.Slug.ValueString(),
+				updateOpts,
+				err,
+			),
+		)
+		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("update: received refreshed cluster: %+v", refreshResult))
+	tflog.Debug(ctx, "update: received refreshed cluster", clusterLogFields(refreshResult))
 
 	refreshState, err = resourceConvert(refreshResult)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("update: failed to convert response to resource Cluster (%+v)", refreshResult),
+			fmt.Sprintf("update: failed to convert response to resource Cluster (%s)", refreshResult.Slug),
 			err.Error(),
 		)
 		return
@@ -713,6 +958,7 @@ UpdateLoop:
 	// Set state details
 	refreshState.ID = state.ID
 	refreshState.Access = state.Access
+	retainConfiguration(&refreshState, desired)
 
 	diags = resp.State.Set(ctx, refreshState)
 	resp.Diagnostics.Append(diags...)
@@ -720,10 +966,14 @@ UpdateLoop:
 
 // Delete deletes an Alias.
 func (r *resource) Delete(ctx context.Context, req tfrsc.DeleteRequest, resp *tfrsc.DeleteResponse) {
-	var state resourceModel
+	ctx = logging.MaskCredentials(ctx)
 
-	refreshDeadline := 5 * time.Minute
-	refreshDelay := 10 * time.Second
+	resp.Diagnostics.Append(r.checkWritable("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state resourceModel
 
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -731,8 +981,62 @@ func (r *resource) Delete(ctx context.Context, req tfrsc.DeleteRequest, resp *tf
 		return
 	}
 
+	refreshDeadline, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			fmt.Sprintf("Bonsai Cluster (%s) is protected from deletion", state.Slug.ValueString()),
+			"The cluster can't be destroyed while deletion_protection is true. "+
+				"To destroy it, set deletion_protection to false and apply "+
+				"that change first.",
+		)
+		return
+	}
+
+	if !state.ForceDestroy.ValueBool() {
+		current, err := r.client.Cluster.GetBySlug(ctx, state.Slug.ValueString())
+		if err != nil {
+			// Already gone; nothing left to destroy.
+			if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Bonsai Cluster (%s) from the Bonsai API", state.Slug.ValueString()),
+				fmt.Sprintf("Failed while checking the cluster's documents before destroying it: %s", err),
+			)
+			return
+		}
+
+		if current.Stats.Docs > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force_destroy"),
+				fmt.Sprintf("Bonsai Cluster (%s) still holds documents", state.Slug.ValueString()),
+				fmt.Sprintf(
+					"The cluster holds %d documents, and can't be destroyed "+
+						"while force_destroy is false. To destroy it along with "+
+						"its documents, set force_destroy to true and apply "+
+						"that change first.",
+					current.Stats.Docs,
+				),
+			)
+			return
+		}
+	}
+
 	_, err := r.client.Cluster.Destroy(ctx, state.Slug.ValueString())
 	if err != nil {
+		// Already gone, whether destroyed outside of Terraform, or by an
+		// earlier attempt of a retried request; nothing left to destroy.
+		if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			fmt.Sprintf(
 				"failed to destroy cluster (%s)",
@@ -744,47 +1048,48 @@ func (r *resource) Delete(ctx context.Context, req tfrsc.DeleteRequest, resp *tf
 	}
 
 	// wait until it's been deleted
-	refreshCtx, refreshCancel := context.WithDeadline(ctx, time.Now().Add(refreshDeadline))
-	defer refreshCancel()
+	_, err = newDeleteWaiter(
+		r.client,
+		state.Slug.ValueString(),
+		refreshDeadline,
+	).Wait(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			waitErrorSummary("deletion", state.Slug.ValueString(), err),
+			fmt.Sprintf(
+				"Failed while refreshing Cluster (%s) state after destroy request: %s",
+				state.Slug.ValueString(),
+				err,
+			),
+		)
+		return
+	}
+}
 
-RefreshLoop:
-	for {
-		select {
-		case <-refreshCtx.Done():
-			resp.Diagnostics.AddError(
-				fmt.Sprintf(
-					"Timed out while deleting Bonsai Cluster (%s) in time (%d)",
-					state.Slug.ValueString(),
-					refreshDeadline,
-				),
-				ctx.Err().Error(),
-			)
-			return
-		default:
-			result, err := r.client.Cluster.GetBySlug(ctx, state.Slug.ValueString())
-			tflog.Debug(ctx, fmt.Sprintf("found cluster: %+v", result))
-			if err != nil {
-				if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
-					break RefreshLoop
-				}
-				resp.Diagnostics.AddError(
-					"Error refreshing Bonsai Cluster state",
-					fmt.Sprintf(
-						"Failed while refreshing Cluster (%s) state after destroy request, unexpected error: %s",
-						state.Slug.ValueString(),
-						err,
-					),
-				)
-				return
-			}
+// ImportState imports an existing Cluster by its slug.
+//
+// Cluster credentials are only returned by the Bonsai API at creation, so
+// an imported Cluster will have null access credentials.
+func (r *resource) ImportState(ctx context.Context, req tfrsc.ImportStateRequest, resp *tfrsc.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Import Bonsai Cluster",
+			"expected the import identifier to be the slug of an existing cluster",
+		)
+		return
+	}
 
-			// Deprovisioned, but still exists
-			if result.State == bonsai.ClusterStateDeprovisioned {
-				return
-			}
-			// Sleep for a little bit; all of these should be refactored at some point
-			time.Sleep(refreshDelay)
-			continue RefreshLoop
-		}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), req.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Imported Bonsai Cluster (%s) has no access credentials", req.ID),
+		"The Bonsai API only returns cluster credentials when a cluster is created. "+
+			"The access.user, access.password and access.url attributes will be null "+
+			"for imported clusters; retrieve the credentials from the Bonsai.io "+
+			"management panel instead.",
+	)
 }
@@ -0,0 +1,191 @@
+package cluster_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfrsc "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/provider"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceBudgetTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+
+	ctx context.Context
+}
+
+func TestResourceBudgetTestSuite(t *testing.T) {
+	suite.Run(t, &ResourceBudgetTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ResourceBudgetTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ctx = context.Background()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "standard-sm",
+						"price_in_cents": 5000,
+						"billing_interval_in_months": 1,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common", "omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "standard-md",
+						"price_in_cents": 10000,
+						"billing_interval_in_months": 1,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common", "omc/bonsai/eu-west-1/common"]
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"clusters": [
+					{
+						"slug": "search-1234",
+						"name": "search",
+						"plan": {"slug": "standard-sm"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "PROVISIONED"
+					},
+					{
+						"slug": "logs-1234",
+						"name": "logs",
+						"plan": {"slug": "standard-sm"},
+						"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"state": "PROVISIONED"
+					}
+				]
+			}
+		`))
+	})
+}
+
+// configure configures the provider with a budget of maxCents, for an
+// account with 10000 cents of clusters.
+func (s *ResourceBudgetTestSuite) configure(maxCents int64) *providerdata.Data {
+	data, err := test.ConfigureProvider(s.ctx, provider.New(provider.WithVersion("0.1.0-test"))(),
+		test.Argument{Path: path.Root("api_key"), Value: "TerraformTestKey"},
+		test.Argument{Path: path.Root("api_token"), Value: "TerraformTestToken"},
+		test.Argument{Path: path.Root("api_endpoint"), Value: s.Server.URL},
+		test.Argument{Path: path.Root("max_monthly_spend_cents"), Value: maxCents},
+	)
+	s.NoError(err)
+
+	return data
+}
+
+// clusterArguments returns the arguments of a cluster named name.
+func clusterArguments(name, planSlug, spacePath string) []test.Argument {
+	return []test.Argument{
+		{Path: path.Root("name"), Value: name},
+		{Path: path.Root("plan").AtName("slug"), Value: planSlug},
+		{Path: path.Root("space").AtName("path"), Value: spacePath},
+		{Path: path.Root("release").AtName("slug"), Value: "opensearch-2.6.0-mt"},
+	}
+}
+
+// searchState returns the state of the account's search-1234 cluster.
+func searchState() []test.Argument {
+	return append(clusterArguments("search", "standard-sm", "omc/bonsai/us-east-1/common"),
+		test.Argument{Path: path.Root("id"), Value: "search-1234"},
+		test.Argument{Path: path.Root("slug"), Value: "search-1234"},
+	)
+}
+
+// plan modifies the plan of the cluster.
+func (s *ResourceBudgetTestSuite) plan(data *providerdata.Data, change test.ResourcePlan) tfrsc.ModifyPlanResponse {
+	resp, err := test.PlanResource(s.ctx, cluster.NewResource(), data, change)
+	s.NoError(err)
+
+	return resp
+}
+
+// exceeded returns the detail of the budget exceeded error, if any.
+func (s *ResourceBudgetTestSuite) exceeded(resp tfrsc.ModifyPlanResponse) string {
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "Bonsai Monthly Spend Budget Exceeded" {
+			return d.Detail()
+		}
+	}
+
+	s.False(resp.Diagnostics.HasError(), resp.Diagnostics)
+	return ""
+}
+
+func (s *ResourceBudgetTestSuite) TestResource_PlanBudgetTwice() {
+	data := s.configure(8000)
+
+	unchanged := test.ResourcePlan{
+		Prior: searchState(),
+		Plan:  clusterArguments("search", "standard-sm", "omc/bonsai/us-east-1/common"),
+	}
+
+	// Though the account is already over budget, clusters which don't raise
+	// its spend may still be planned, however many times.
+	s.Empty(s.exceeded(s.plan(data, unchanged)))
+	s.Empty(s.exceeded(s.plan(data, unchanged)))
+
+	upgraded := test.ResourcePlan{
+		Prior: searchState(),
+		Plan:  clusterArguments("search", "standard-md", "omc/bonsai/us-east-1/common"),
+	}
+
+	// Planning again estimates the same spend, rather than counting the
+	// cluster twice.
+	for range 2 {
+		s.Contains(s.exceeded(s.plan(data, upgraded)), "planned monthly spend of 15000 cents ($150.00)")
+	}
+}
+
+func (s *ResourceBudgetTestSuite) TestResource_PlanBudgetReplacement() {
+	data := s.configure(8000)
+
+	// Terraform plans the replacement of the cluster, then the cluster
+	// replacing it without its prior state, which is counted once.
+	s.Empty(s.exceeded(s.plan(data, test.ResourcePlan{
+		Prior:           searchState(),
+		Plan:            clusterArguments("search", "standard-sm", "omc/bonsai/eu-west-1/common"),
+		RequiresReplace: path.Paths{path.Root("space").AtName("path")},
+	})))
+	s.Empty(s.exceeded(s.plan(data, test.ResourcePlan{
+		Plan: clusterArguments("search", "standard-sm", "omc/bonsai/eu-west-1/common"),
+	})))
+
+	// New clusters are counted alongside the account's.
+	s.Contains(s.exceeded(s.plan(data, test.ResourcePlan{
+		Plan: clusterArguments("new", "standard-sm", "omc/bonsai/eu-west-1/common"),
+	})), "planned monthly spend of 15000 cents ($150.00)")
+}
+
+func (s *ResourceBudgetTestSuite) TestResource_PlanBudgetNewClusters() {
+	data := s.configure(18000)
+
+	// Each new cluster fits within the budget alone, but not together.
+	s.Empty(s.exceeded(s.plan(data, test.ResourcePlan{
+		Plan: clusterArguments("first", "standard-sm", "omc/bonsai/us-east-1/common"),
+	})))
+	s.Contains(s.exceeded(s.plan(data, test.ResourcePlan{
+		Plan: clusterArguments("second", "standard-sm", "omc/bonsai/us-east-1/common"),
+	})), "planned monthly spend of 20000 cents ($200.00)")
+}
@@ -0,0 +1,91 @@
+package cluster_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/provider"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceDeleteTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+
+	ctx     context.Context
+	deletes atomic.Int64
+}
+
+func TestResourceDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &ResourceDeleteTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ResourceDeleteTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ctx = context.Background()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`{"plans": []}`))
+	})
+
+	s.ServeMux.Delete(bonsai.ClusterAPIBasePath+"/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		attempt := s.deletes.Add(1)
+
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+
+		// The first attempt to destroy gateway-1234 destroys it, but fails
+		// at the gateway, such that the retried attempt no longer finds it.
+		if chi.URLParam(r, "slug") == "gateway-1234" && attempt == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"errors": ["bad gateway"], "status": 502}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": ["not found"], "status": 404}`))
+	})
+}
+
+func (s *ResourceDeleteTestSuite) SetupTest() {
+	s.deletes.Store(0)
+}
+
+// delete deletes the cluster, with the provider's retrying client.
+func (s *ResourceDeleteTestSuite) delete(slug string) {
+	data, err := test.ConfigureProvider(s.ctx, provider.New(provider.WithVersion("0.1.0-test"))(),
+		test.Argument{Path: path.Root("api_key"), Value: "TerraformTestKey"},
+		test.Argument{Path: path.Root("api_token"), Value: "TerraformTestToken"},
+		test.Argument{Path: path.Root("api_endpoint"), Value: s.Server.URL},
+		test.Argument{Path: path.Root("retry").AtName("min_backoff"), Value: "1ms"},
+	)
+	s.NoError(err)
+
+	resp, err := test.DeleteResource(s.ctx, cluster.NewResource(), data,
+		test.Argument{Path: path.Root("id"), Value: slug},
+		test.Argument{Path: path.Root("slug"), Value: slug},
+		test.Argument{Path: path.Root("force_destroy"), Value: true},
+		test.Argument{Path: path.Root("plan").AtName("slug"), Value: "sandbox"},
+		test.Argument{Path: path.Root("space").AtName("path"), Value: "omc/bonsai/us-east-1/common"},
+		test.Argument{Path: path.Root("release").AtName("slug"), Value: "opensearch-2.6.0-mt"},
+	)
+	s.NoError(err)
+	s.False(resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func (s *ResourceDeleteTestSuite) TestResource_DeleteRetriedAfterDestroying() {
+	s.delete("gateway-1234")
+	s.Equal(int64(2), s.deletes.Load())
+}
+
+func (s *ResourceDeleteTestSuite) TestResource_DeleteDestroyedOutsideTerraform() {
+	s.delete("missing-1234")
+	s.Equal(int64(1), s.deletes.Load())
+}
@@ -0,0 +1,128 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfrsc "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/provider"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcePolicyTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+
+	ctx  context.Context
+	data *providerdata.Data
+}
+
+func TestResourcePolicyTestSuite(t *testing.T) {
+	suite.Run(t, &ResourcePolicyTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ResourcePolicyTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ctx = context.Background()
+
+	data, err := test.ConfigureProvider(s.ctx, provider.New(provider.WithVersion("0.1.0-test"))(),
+		test.Argument{Path: path.Root("api_key"), Value: "TerraformTestKey"},
+		test.Argument{Path: path.Root("api_token"), Value: "TerraformTestToken"},
+		test.Argument{Path: path.Root("api_endpoint"), Value: s.Server.URL},
+		test.Argument{Path: path.Root("allowed_spaces"), Value: []string{"omc/bonsai/us-*"}},
+		test.Argument{Path: path.Root("allowed_releases"), Value: []string{"opensearch-2.6.0-mt"}},
+	)
+	s.Require().NoError(err)
+	s.data = data
+}
+
+// plan modifies the plan of the cluster.
+func (s *ResourcePolicyTestSuite) plan(change test.ResourcePlan) tfrsc.ModifyPlanResponse {
+	resp, err := test.PlanResource(s.ctx, cluster.NewResource(), s.data, change)
+	s.NoError(err)
+
+	return resp
+}
+
+// violations returns the paths of the policy violations, and of the
+// restricted attributes which aren't known.
+func violations(resp tfrsc.ModifyPlanResponse) path.Paths {
+	var paths path.Paths
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() != "Bonsai Cluster Policy Violation" {
+			continue
+		}
+		if withPath, ok := d.(interface{ Path() path.Path }); ok {
+			paths = append(paths, withPath.Path())
+		}
+	}
+
+	return paths
+}
+
+func (s *ResourcePolicyTestSuite) TestResource_PlanPolicy() {
+	resp := s.plan(test.ResourcePlan{
+		Plan: test.Arguments(map[string]any{
+			"name":         "search",
+			"plan.slug":    "sandbox",
+			"space.path":   "omc/bonsai/eu-west-1/common",
+			"release.slug": "opensearch-2.6.0-mt",
+		}),
+	})
+	s.Equal(path.Paths{path.Root("space").AtName("path")}, violations(resp))
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), `The space "omc/bonsai/eu-west-1/common" is not permitted`)
+
+	resp = s.plan(test.ResourcePlan{
+		Plan: test.Arguments(map[string]any{
+			"name":         "search",
+			"plan.slug":    "sandbox",
+			"space.path":   "omc/bonsai/us-east-1/common",
+			"release.slug": "opensearch-2.6.0-mt",
+		}),
+	})
+	s.Empty(violations(resp))
+}
+
+func (s *ResourcePolicyTestSuite) TestResource_PlanPolicyOmitted() {
+	// Neither the space nor the release may be left to the Bonsai API's
+	// defaults while they're restricted.
+	resp := s.plan(test.ResourcePlan{
+		Plan: test.Arguments(map[string]any{
+			"name":      "search",
+			"plan.slug": "sandbox",
+		}),
+	})
+	s.Equal(path.Paths{path.Root("space").AtName("path"), path.Root("release").AtName("slug")}, violations(resp))
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "The space must be known when the cluster is planned")
+}
+
+func (s *ResourcePolicyTestSuite) TestResource_PlanPolicyUnknown() {
+	resp := s.plan(test.ResourcePlan{
+		Plan: test.Arguments(map[string]any{
+			"name":         "search",
+			"plan.slug":    "sandbox",
+			"space.path":   types.StringUnknown(),
+			"release.slug": "opensearch-2.6.0-mt",
+		}),
+	})
+	s.Equal(path.Paths{path.Root("space").AtName("path")}, violations(resp))
+}
+
+func (s *ResourcePolicyTestSuite) TestResource_PlanPolicyUnchanged() {
+	// Existing clusters may still be managed after the policy is tightened.
+	state := test.Arguments(map[string]any{
+		"id":           "search-1234",
+		"slug":         "search-1234",
+		"name":         "search",
+		"plan.slug":    "sandbox",
+		"space.path":   "omc/bonsai/eu-west-1/common",
+		"release.slug": "elasticsearch-7.10.2",
+	})
+
+	s.Empty(violations(s.plan(test.ResourcePlan{Prior: state, Plan: state})))
+}
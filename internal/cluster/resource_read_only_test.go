@@ -0,0 +1,112 @@
+package cluster_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfrsc "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceReadOnlyTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+
+	ctx      context.Context
+	requests atomic.Int64
+}
+
+func TestResourceReadOnlyTestSuite(t *testing.T) {
+	suite.Run(t, &ResourceReadOnlyTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ResourceReadOnlyTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ctx = context.Background()
+
+	// Read-only operations must fail before any request is made.
+	s.ServeMux.HandleFunc("/*", func(w http.ResponseWriter, _ *http.Request) {
+		s.requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"errors": ["unexpected request"], "status": 500}`))
+	})
+}
+
+func (s *ResourceReadOnlyTestSuite) SetupTest() {
+	s.requests.Store(0)
+}
+
+// newResource returns a Cluster resource configured by a read-only
+// provider, and its schema.
+func (s *ResourceReadOnlyTestSuite) newResource() (tfrsc.Resource, tfrsc.SchemaResponse) {
+	r := cluster.NewResource()
+
+	configurable, ok := r.(tfrsc.ResourceWithConfigure)
+	s.True(ok)
+
+	var configureResp tfrsc.ConfigureResponse
+	configurable.Configure(s.ctx, tfrsc.ConfigureRequest{
+		ProviderData: &providerdata.Data{Client: s.Client, ReadOnly: true},
+	}, &configureResp)
+	s.False(configureResp.Diagnostics.HasError())
+
+	var schemaResp tfrsc.SchemaResponse
+	r.Schema(s.ctx, tfrsc.SchemaRequest{}, &schemaResp)
+	s.False(schemaResp.Diagnostics.HasError())
+
+	return r, schemaResp
+}
+
+// emptyValue returns an empty value of the resource schema's type.
+func (s *ResourceReadOnlyTestSuite) emptyValue(schemaResp tfrsc.SchemaResponse) tftypes.Value {
+	return tftypes.NewValue(schemaResp.Schema.Type().TerraformType(s.ctx), nil)
+}
+
+func (s *ResourceReadOnlyTestSuite) requireReadOnlyError(diags diag.Diagnostics, operation string) {
+	s.True(diags.HasError())
+	s.Equal("Bonsai Provider is Read-Only", diags.Errors()[0].Summary())
+	s.Contains(diags.Errors()[0].Detail(), "Unable to "+operation+" the cluster")
+	s.Zero(s.requests.Load())
+}
+
+func (s *ResourceReadOnlyTestSuite) TestReadOnly_Create() {
+	r, schemaResp := s.newResource()
+
+	resp := tfrsc.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)}}
+	r.Create(s.ctx, tfrsc.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)},
+	}, &resp)
+
+	s.requireReadOnlyError(resp.Diagnostics, "create")
+}
+
+func (s *ResourceReadOnlyTestSuite) TestReadOnly_Update() {
+	r, schemaResp := s.newResource()
+
+	resp := tfrsc.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)}}
+	r.Update(s.ctx, tfrsc.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)},
+	}, &resp)
+
+	s.requireReadOnlyError(resp.Diagnostics, "update")
+}
+
+func (s *ResourceReadOnlyTestSuite) TestReadOnly_Delete() {
+	r, schemaResp := s.newResource()
+
+	resp := tfrsc.DeleteResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)}}
+	r.Delete(s.ctx, tfrsc.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: s.emptyValue(schemaResp)},
+	}, &resp)
+
+	s.requireReadOnlyError(resp.Diagnostics, "delete")
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/wait"
 )
 
 func testClusterExists(resourceName string, client *bonsai.Client) resource.TestCheckFunc {
@@ -60,6 +63,73 @@ func testClusterDestroyed(resourceName string, client *bonsai.Client) resource.T
 	}
 }
 
+// testClusterDisappears destroys the cluster outside of Terraform, and
+// awaits its deprovisioning.
+func testClusterDisappears(resourceName string, client *bonsai.Client) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("testClusterDisappears: not found: %s", resourceName)
+		}
+
+		if _, err := client.Cluster.Destroy(context.TODO(), rs.Primary.ID); err != nil {
+			return fmt.Errorf("testClusterDisappears: failed to destroy cluster: %w", err)
+		}
+
+		w := &wait.Waiter[bonsai.Cluster]{
+			Target: []string{"deleted"},
+			Refresh: func(ctx context.Context) (bonsai.Cluster, string, error) {
+				result, err := client.Cluster.GetBySlug(ctx, rs.Primary.ID)
+				if errors.Is(err, bonsai.ErrHTTPStatusNotFound) ||
+					(err == nil && result.State == bonsai.ClusterStateDeprovisioned) {
+					return result, "deleted", nil
+				}
+				return result, "deleting", err
+			},
+			Timeout: 10 * time.Minute,
+		}
+		_, err := w.Wait(context.TODO())
+		return err
+	}
+}
+
+func (s *ClusterTestSuite) TestCluster_ResourceDisappears() {
+	clusterName := fmt.Sprintf("bonsai test %s", acctest.RandString(16))
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: s.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config: fmt.Sprintf(`
+                    resource "bonsai_cluster" "test" {
+                        name = "%s"
+
+                        plan = { 
+							slug = "sandbox"
+						}
+
+                        space = { 
+							path = "omc/bonsai/us-east-1/common"
+						}
+
+                        release = { 
+							slug = "opensearch-2.6.0-mt"
+						}
+                    }
+                `, clusterName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					testClusterDisappears("bonsai_cluster.test", s.Client),
+				),
+				// The refresh after apply should remove the cluster from
+				// state, planning its re-creation rather than erroring.
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func (s *ClusterTestSuite) TestCluster_Resource() {
 	clusterSuffix := acctest.RandString(16)
 	clusterName := fmt.Sprintf("bonsai test %s", clusterSuffix)
@@ -88,7 +158,7 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
                     }
                 `,
 				// Errors have weird line breaks.
-				ExpectError: regexp.MustCompile(`.*?Plan\s+'?[-_a-zA-Z]+'?\s+not\s+found.\s+Please\s+use\s+the\s+/plans\s+endpoint\s+for\s+a\s+list\s+of\s+available\s+plans`), //nolint:lll
+				ExpectError: regexp.MustCompile(`(?s)Plan\s+"invalid-ref"\s+was\s+not\s+found.\s+Available\s+plans:\s+.*?"sandbox"`),
 			},
 			{
 				ResourceName: "bonsai_cluster.test",
@@ -109,7 +179,7 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						}
                     }
                 `,
-				ExpectError: regexp.MustCompile(`.*?Space\s+'?[-_a-zA-Z]+'?\s+not\s+found.\s+Please\s+use\s+the\s+/spaces\s+endpoint\s+for\s+a\s+list\s+of\s+available\s+spaces`), //nolint:lll
+				ExpectError: regexp.MustCompile(`(?s)Space\s+"invalid-ref"\s+is\s+not\s+available\s+for\s+plan\s+"sandbox".\s+Available\s+spaces\s+for\s+this\s+plan:\s+.*?"omc/bonsai/us-east-1/common"`), //nolint:lll
 			},
 			{
 				ResourceName: "bonsai_cluster.test",
@@ -130,7 +200,32 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						}
                     }
                 `,
-				ExpectError: regexp.MustCompile(`.*?(Release|Version)\s+'?[-_a-zA-Z]+'?\s+not\s+found.\s+Please\s+use\s+the\s+/releases\s+endpoint\s+for\s+a\s+list\s+of\s+available\s+(releases|versions)`),
+				ExpectError: regexp.MustCompile(`(?s)Release\s+"invalid-ref"\s+is\s+not\s+available\s+for\s+plan\s+"sandbox".\s+Available\s+releases\s+for\s+this\s+plan:\s+.*?"opensearch-2.6.0-mt"`), //nolint:lll
+			},
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config: `
+                    resource "bonsai_cluster" "test" {
+                        name = "never-created-test-cluster"
+
+                        plan = { 
+							slug = "sandbox"
+						}
+
+                        space = { 
+							path = "omc/bonsai/us-east-1/common"
+						}
+
+                        release = { 
+							slug = "opensearch-2.6.0-mt"
+						}
+
+                        timeouts {
+							create = "twenty minutes"
+						}
+                    }
+                `,
+				ExpectError: regexp.MustCompile(`.*?must\s+be\s+a\s+string\s+containing\s+a\s+sequence\s+of\s+decimal\s+numbers`),
 			},
 			// Create and Read testing
 			{
@@ -150,6 +245,10 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
                         release = { 
 							slug = "opensearch-2.6.0-mt"
 						}
+
+                        timeouts {
+							create = "30m"
+						}
                     }
                 `, clusterName),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -158,8 +257,24 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						"name",
 						clusterName,
 					),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "timeouts.create", "30m"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      "bonsai_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Credentials are only returned during creation, the
+				// create-time message/monitor aren't retrievable afterward,
+				// and timeouts only exist within the configuration.
+				ImportStateVerifyIgnore: []string{
+					"access",
+					"message",
+					"monitor",
+					"timeouts",
+				},
+			},
 			// Update testing
 			// Update name only
 			{
@@ -181,6 +296,11 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						}
 			        }
 			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("bonsai_cluster.test", "name", clusterSuffix),
 				),
@@ -205,6 +325,11 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						}
 			        }
 			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("bonsai_cluster.test", "plan.slug", "sandbox"),
 				),
@@ -229,11 +354,168 @@ func (s *ClusterTestSuite) TestCluster_Resource() {
 						}
 			        }
 			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("bonsai_cluster.test", "name", fmt.Sprintf("bonsai test cluster %s", clusterSuffix)),
 					resource.TestCheckResourceAttr("bonsai_cluster.test", "plan.slug", "sandbox"),
 				),
 			},
+			// Replacement testing
+			// Update Release, which can't be changed in place
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config: fmt.Sprintf(`
+			        resource "bonsai_cluster" "test" {
+			            name = "bonsai test cluster %s"
+
+			            plan = {
+							slug = "sandbox"
+						}
+
+			            space = {
+							path = "omc/bonsai/us-east-1/common"
+						}
+
+			            release = {
+							slug = "elasticsearch-7.10.2"
+						}
+			        }
+			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "release.slug", "elasticsearch-7.10.2"),
+				),
+			},
+			// Update Space, which can't be changed in place
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config: fmt.Sprintf(`
+			        resource "bonsai_cluster" "test" {
+			            name = "bonsai test cluster %s"
+
+			            plan = {
+							slug = "sandbox"
+						}
+
+			            space = {
+							path = "omc/bonsai/eu-west-1/common"
+						}
+
+			            release = {
+							slug = "elasticsearch-7.10.2"
+						}
+			        }
+			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "space.path", "omc/bonsai/eu-west-1/common"),
+				),
+			},
+			// Remove the Space and Release from configuration, which keeps them
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config: fmt.Sprintf(`
+			        resource "bonsai_cluster" "test" {
+			            name = "bonsai test cluster %s"
+
+			            plan = {
+							slug = "sandbox"
+						}
+
+			            space = {}
+
+			            release = {}
+			        }
+			    `, clusterSuffix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "space.path", "omc/bonsai/eu-west-1/common"),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "release.slug", "elasticsearch-7.10.2"),
+				),
+			},
+		},
+	})
+}
+
+func (s *ClusterTestSuite) TestCluster_ResourceDeletionProtection() {
+	clusterName := fmt.Sprintf("bonsai test %s", acctest.RandString(16))
+	config := func(deletionProtection bool) string {
+		return fmt.Sprintf(`
+			resource "bonsai_cluster" "test" {
+				name = "%s"
+
+				plan = {
+					slug = "sandbox"
+				}
+
+				space = {
+					path = "omc/bonsai/us-east-1/common"
+				}
+
+				release = {
+					slug = "opensearch-2.6.0-mt"
+				}
+
+				deletion_protection = %t
+				force_destroy       = true
+			}
+		`, clusterName, deletionProtection)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: s.ProtoV6ProviderFactories,
+		CheckDestroy:             testClusterDestroyed("bonsai_cluster.test", s.Client),
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config:       config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "force_destroy", "true"),
+				),
+			},
+			// Destroying a protected cluster fails
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config:       config(true),
+				Destroy:      true,
+				ExpectError:  regexp.MustCompile(`is\s+protected\s+from\s+deletion`),
+			},
+			// Lifting the protection doesn't touch the Bonsai API, and allows
+			// the cluster to be destroyed.
+			{
+				ResourceName: "bonsai_cluster.test",
+				Config:       config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bonsai_cluster.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testClusterExists("bonsai_cluster.test", s.Client),
+					resource.TestCheckResourceAttr("bonsai_cluster.test", "deletion_protection", "false"),
+				),
+			},
 		},
 	})
 }
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/wait"
+)
+
+// Waiter states, describing a Cluster's progress through an operation.
+const (
+	waitStateNotFound     = "not found"
+	waitStateProvisioning = "provisioning"
+	waitStateAvailable    = "available"
+	waitStateUpdating     = "updating"
+	waitStateUpdated      = "updated"
+	waitStateDeleting     = "deleting"
+	waitStateDeleted      = "deleted"
+)
+
+// Backoff bounds used while polling for Cluster state changes.
+const (
+	waitMinBackoff = 5 * time.Second
+	waitMaxBackoff = 30 * time.Second
+	// waitUpdateDelay allows an update request to be reflected in the
+	// Cluster's state before it's first polled.
+	waitUpdateDelay = 10 * time.Second
+)
+
+// logWaitProgress reports each Cluster refresh while waiting.
+func logWaitProgress(slug string) wait.ProgressFunc {
+	return func(ctx context.Context, attempt int, state string, next time.Duration) {
+		tflog.Debug(ctx, "awaiting cluster state", map[string]interface{}{
+			"slug":    slug,
+			"attempt": attempt,
+			"state":   state,
+			"next":    next.String(),
+		})
+	}
+}
+
+// newCreateWaiter returns a Waiter which completes once the newly created
+// Cluster can be found, with its Space fully provisioned.
+func newCreateWaiter(client *bonsai.ClusterClient, slug string, timeout time.Duration) *wait.Waiter[bonsai.Cluster] {
+	return &wait.Waiter[bonsai.Cluster]{
+		Pending: []string{waitStateNotFound, waitStateProvisioning},
+		Target:  []string{waitStateAvailable},
+		Refresh: func(ctx context.Context) (bonsai.Cluster, string, error) {
+			result, err := client.Cluster.GetBySlug(ctx, slug)
+			if err != nil {
+				// The cluster may not be discoverable immediately after creation.
+				if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+					return result, waitStateNotFound, nil
+				}
+				return result, "", err
+			}
+
+			if unavailableRegexp.MatchString(result.Space.Path) || unavailableRegexp.MatchString(result.Space.URI) {
+				return result, waitStateProvisioning, nil
+			}
+
+			return result, waitStateAvailable, nil
+		},
+		Timeout:    timeout,
+		MinBackoff: waitMinBackoff,
+		MaxBackoff: waitMaxBackoff,
+		OnProgress: logWaitProgress(slug),
+	}
+}
+
+// newUpdateWaiter returns a Waiter which completes once the Cluster is no
+// longer updating its plan, and has the desired name.
+func newUpdateWaiter(client *bonsai.ClusterClient, slug string, desired resourceModel, timeout time.Duration) *wait.Waiter[bonsai.Cluster] {
+	return &wait.Waiter[bonsai.Cluster]{
+		Pending: []string{waitStateUpdating},
+		Target:  []string{waitStateUpdated},
+		Refresh: func(ctx context.Context) (bonsai.Cluster, string, error) {
+			result, err := client.Cluster.GetBySlug(ctx, slug)
+			if err != nil {
+				return result, "", err
+			}
+
+			if result.State == bonsai.ClusterStateUpdatingPlan || result.Name != desired.Name.ValueString() {
+				return result, waitStateUpdating, nil
+			}
+
+			return result, waitStateUpdated, nil
+		},
+		Timeout:    timeout,
+		Delay:      waitUpdateDelay,
+		MinBackoff: waitMinBackoff,
+		MaxBackoff: waitMaxBackoff,
+		OnProgress: logWaitProgress(slug),
+	}
+}
+
+// newDeleteWaiter returns a Waiter which completes once the Cluster is
+// either deprovisioned, or can no longer be found.
+func newDeleteWaiter(client *bonsai.ClusterClient, slug string, timeout time.Duration) *wait.Waiter[bonsai.Cluster] {
+	return &wait.Waiter[bonsai.Cluster]{
+		Pending: []string{waitStateDeleting},
+		Target:  []string{waitStateDeleted},
+		Refresh: func(ctx context.Context) (bonsai.Cluster, string, error) {
+			result, err := client.Cluster.GetBySlug(ctx, slug)
+			if err != nil {
+				if errors.Is(err, bonsai.ErrHTTPStatusNotFound) {
+					return result, waitStateDeleted, nil
+				}
+				return result, "", err
+			}
+
+			// Deprovisioned, but still exists
+			if result.State == bonsai.ClusterStateDeprovisioned {
+				return result, waitStateDeleted, nil
+			}
+
+			return result, waitStateDeleting, nil
+		},
+		Timeout:    timeout,
+		MinBackoff: waitMinBackoff,
+		MaxBackoff: waitMaxBackoff,
+		OnProgress: logWaitProgress(slug),
+	}
+}
+
+// waitErrorSummary describes a failed wait for diagnostics.
+func waitErrorSummary(operation, slug string, err error) string {
+	if errors.Is(err, wait.ErrTimeout) {
+		return fmt.Sprintf("Timed out while awaiting Bonsai Cluster (%s) %s", slug, operation)
+	}
+	if errors.Is(err, context.Canceled) {
+		return fmt.Sprintf("Cancelled while awaiting Bonsai Cluster (%s) %s", slug, operation)
+	}
+	return fmt.Sprintf("Error while awaiting Bonsai Cluster (%s) %s", slug, operation)
+}
@@ -0,0 +1,135 @@
+// Package filter implements the filtering and sorting arguments shared by
+// the provider's list data sources.
+package filter
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+)
+
+// Sort orders accepted by a list data source's sort_order argument.
+const (
+	SortOrderAscending  = "asc"
+	SortOrderDescending = "desc"
+)
+
+// Regexp compiles the regular expression held by value, returning nil if
+// value is null.
+func Regexp(value types.String, p path.Path) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	re, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q isn't a valid regular expression.\n\nError: %s", value.ValueString(), err),
+		)
+		return nil, diags
+	}
+
+	return re, diags
+}
+
+// OneOf reports an error if value is set to anything but one of allowed.
+func OneOf(value types.String, p path.Path, allowed ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() || slices.Contains(allowed, value.ValueString()) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		p,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Expected one of %q, got %q.", allowed, value.ValueString()),
+	)
+
+	return diags
+}
+
+// Equal reports whether actual matches the filter value, which matches any
+// value if null.
+func Equal(value types.String, actual string) bool {
+	return value.IsNull() || value.IsUnknown() || value.ValueString() == actual
+}
+
+// EqualFold is like Equal, but case-insensitive.
+func EqualFold(value types.String, actual string) bool {
+	return value.IsNull() || value.IsUnknown() || strings.EqualFold(value.ValueString(), actual)
+}
+
+// EqualBool reports whether actual matches the filter value, which matches
+// any value if null.
+func EqualBool(value types.Bool, actual bool) bool {
+	return value.IsNull() || value.IsUnknown() || value.ValueBool() == actual
+}
+
+// AtMost reports whether actual is no greater than the filter value, which
+// matches any value if null.
+func AtMost(value types.Int64, actual int64) bool {
+	return value.IsNull() || value.IsUnknown() || actual <= value.ValueInt64()
+}
+
+// Glob reports whether actual matches any of the filter's glob patterns,
+// which match any value if nil; see policy.MatchGlob.
+func Glob(patterns []types.String, actual string) bool {
+	return patterns == nil || slices.ContainsFunc(patterns, func(pattern types.String) bool {
+		return !pattern.IsNull() && !pattern.IsUnknown() && policy.MatchGlob(pattern.ValueString(), actual)
+	})
+}
+
+// Sort stably sorts items by the key of the sort_by argument, in the order
+// of the sort_order argument, leaving items unsorted if sort_by is null.
+//
+// keys maps each accepted sort_by value to a function comparing two items,
+// in ascending order.
+func Sort[T any](items []T, sortBy, sortOrder types.String, keys map[string]func(a, b T) int) {
+	if sortBy.IsNull() || sortBy.IsUnknown() {
+		return
+	}
+
+	compare, ok := keys[sortBy.ValueString()]
+	if !ok {
+		return
+	}
+
+	descending := sortOrder.ValueString() == SortOrderDescending
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		if descending {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+}
+
+// ByString compares items by a string key, for use with Sort.
+func ByString[T any](key func(T) string) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// SortKeys returns the sort_by values accepted by keys, sorted.
+func SortKeys[T any](keys map[string]func(a, b T) int) []string {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,82 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type FilterTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (s *FilterTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *FilterTestSuite) TestRegexp() {
+	re, diags := filter.Regexp(types.StringNull(), path.Root("name_regex"))
+	s.False(diags.HasError())
+	s.Nil(re)
+
+	re, diags = filter.Regexp(types.StringValue("^prod-"), path.Root("name_regex"))
+	s.False(diags.HasError())
+	s.True(re.MatchString("prod-search"))
+
+	_, diags = filter.Regexp(types.StringValue("("), path.Root("name_regex"))
+	s.True(diags.HasError())
+}
+
+func (s *FilterTestSuite) TestOneOf() {
+	s.False(filter.OneOf(types.StringNull(), path.Root("sort_by"), "name").HasError())
+	s.False(filter.OneOf(types.StringValue("name"), path.Root("sort_by"), "name").HasError())
+	s.True(filter.OneOf(types.StringValue("price"), path.Root("sort_by"), "name").HasError())
+}
+
+func (s *FilterTestSuite) TestEqual() {
+	s.True(filter.Equal(types.StringNull(), "sandbox"))
+	s.True(filter.Equal(types.StringValue("sandbox"), "sandbox"))
+	s.False(filter.Equal(types.StringValue("sandbox"), "Sandbox"))
+	s.True(filter.EqualFold(types.StringValue("provisioned"), "PROVISIONED"))
+
+	s.True(filter.EqualBool(types.BoolNull(), true))
+	s.False(filter.EqualBool(types.BoolValue(false), true))
+
+	s.True(filter.AtMost(types.Int64Null(), 5000))
+	s.True(filter.AtMost(types.Int64Value(5000), 5000))
+	s.False(filter.AtMost(types.Int64Value(4999), 5000))
+}
+
+func (s *FilterTestSuite) TestGlob() {
+	s.True(filter.Glob(nil, "aws-us-east-1"))
+	s.False(filter.Glob([]types.String{}, "aws-us-east-1"))
+	s.True(filter.Glob([]types.String{types.StringValue("aws-eu-*"), types.StringValue("aws-us-*")}, "aws-us-east-1"))
+	s.False(filter.Glob([]types.String{types.StringValue("aws-us-west-?")}, "aws-us-east-1"))
+}
+
+func (s *FilterTestSuite) TestSort() {
+	keys := map[string]func(a, b string) int{
+		"value": filter.ByString(func(v string) string { return v }),
+	}
+
+	items := []string{"b", "c", "a"}
+	filter.Sort(items, types.StringNull(), types.StringNull(), keys)
+	s.Equal([]string{"b", "c", "a"}, items)
+
+	filter.Sort(items, types.StringValue("value"), types.StringNull(), keys)
+	s.Equal([]string{"a", "b", "c"}, items)
+
+	filter.Sort(items, types.StringValue("value"), types.StringValue(filter.SortOrderDescending), keys)
+	s.Equal([]string{"c", "b", "a"}, items)
+
+	s.Equal([]string{"value"}, filter.SortKeys(keys))
+}
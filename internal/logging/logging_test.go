@@ -0,0 +1,125 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testUsername = "xv8kc2mf9q"
+	testPassword = "b7z4nt1pwl"
+)
+
+type LoggingTestSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	sink *bytes.Buffer
+	ctx  context.Context
+}
+
+func TestLoggingTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggingTestSuite))
+}
+
+func (s *LoggingTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.sink = new(bytes.Buffer)
+	s.ctx = logging.MaskCredentials(tflogtest.RootLogger(context.Background(), s.sink))
+}
+
+// requireNoCredentials asserts that nothing logged to the sink contains the
+// test credentials.
+func (s *LoggingTestSuite) requireNoCredentials() {
+	s.NotEmpty(s.sink.String(), "expected output to be logged")
+	s.NotContains(s.sink.String(), testUsername)
+	s.NotContains(s.sink.String(), testPassword)
+}
+
+func (s *LoggingTestSuite) TestMaskCredentials_FieldKeys() {
+	tflog.Debug(s.ctx, "fields", map[string]interface{}{
+		"user":      testUsername,
+		"password":  testPassword,
+		"api_key":   testUsername,
+		"api_token": testPassword,
+	})
+	s.requireNoCredentials()
+}
+
+func (s *LoggingTestSuite) TestMaskCredentials_URLUserinfo() {
+	url := fmt.Sprintf("https://%s:%s@test-1234.us-east-1.bonsaisearch.net:443", testUsername, testPassword)
+
+	tflog.Debug(s.ctx, "url in message: "+url)
+	tflog.Debug(s.ctx, "url in field", map[string]interface{}{"url": url})
+	s.requireNoCredentials()
+	s.Contains(s.sink.String(), "test-1234.us-east-1.bonsaisearch.net")
+}
+
+func (s *LoggingTestSuite) TestMaskCredentials_FormattedClusterAccess() {
+	result := bonsai.ClustersResultCreate{
+		Message: "Your cluster is being provisioned.",
+		Access: bonsai.ClusterAccess{
+			Host:     "test-1234.us-east-1.bonsaisearch.net",
+			Port:     443,
+			Scheme:   "https",
+			Username: testUsername,
+			Password: testPassword,
+			URL:      fmt.Sprintf("https://%s:%s@test-1234.us-east-1.bonsaisearch.net", testUsername, testPassword),
+		},
+	}
+
+	tflog.Debug(s.ctx, fmt.Sprintf("received cluster %+v", result))
+	s.requireNoCredentials()
+
+	// Without field names, the credentials are only recognizable by value.
+	ctx := logging.MaskSecrets(s.ctx, result.Access.Username, result.Access.Password)
+	tflog.Debug(ctx, fmt.Sprintf("received cluster %v", result))
+	s.requireNoCredentials()
+}
+
+func (s *LoggingTestSuite) TestMaskCredentials_FormattedObjectValue() {
+	access := types.ObjectValueMust(
+		map[string]attr.Type{
+			"host":     types.StringType,
+			"user":     types.StringType,
+			"password": types.StringType,
+		},
+		map[string]attr.Value{
+			"host":     types.StringValue("test-1234.us-east-1.bonsaisearch.net"),
+			"user":     types.StringValue(testUsername),
+			"password": types.StringValue(testPassword),
+		},
+	)
+
+	tflog.Debug(s.ctx, fmt.Sprintf("read state %v", access))
+	s.requireNoCredentials()
+}
+
+func (s *LoggingTestSuite) TestMaskSecrets() {
+	ctx := logging.MaskSecrets(s.ctx, testUsername, testPassword, "")
+
+	tflog.Debug(ctx, fmt.Sprintf("credentials %s and %s", testUsername, testPassword), map[string]interface{}{
+		"unrelated": "prefix-" + testPassword,
+	})
+	s.requireNoCredentials()
+}
+
+func (s *LoggingTestSuite) TestRedactURL() {
+	s.Equal(
+		"https://REDACTED@test-1234.us-east-1.bonsaisearch.net",
+		logging.RedactURL(fmt.Sprintf("https://%s:%s@test-1234.us-east-1.bonsaisearch.net", testUsername, testPassword)),
+	)
+	s.Equal("https://api.bonsai.io", logging.RedactURL("https://api.bonsai.io"))
+	s.Equal("REDACTED", logging.RedactURL("://"+testPassword))
+}
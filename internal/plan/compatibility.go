@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// Compatibility describes the Spaces and Releases on which a Plan's clusters
+// may be provisioned.
+type Compatibility struct {
+	Plan     string
+	Spaces   []string
+	Releases []string
+}
+
+// NewCompatibility builds the Compatibility of a Plan, as modeled by its
+// available_spaces and available_releases attributes.
+func NewCompatibility(ctx context.Context, p bonsai.Plan) (Compatibility, error) {
+	m, err := convert(ctx, p)
+	if err != nil {
+		return Compatibility{}, err
+	}
+
+	c := Compatibility{Plan: m.Slug.ValueString()}
+
+	if !m.AvailableSpaces.IsNull() {
+		var spaces []availableSpaceModel
+		diags := m.AvailableSpaces.ElementsAs(ctx, &spaces, false)
+		if diags.HasError() {
+			return c, fmt.Errorf("failed reading available spaces: %s - %s", diags[0].Summary(), diags[0].Detail())
+		}
+		for _, s := range spaces {
+			c.Spaces = append(c.Spaces, s.Path.ValueString())
+		}
+	}
+
+	if !m.AvailableReleases.IsNull() {
+		var releases []availableReleaseModel
+		diags := m.AvailableReleases.ElementsAs(ctx, &releases, false)
+		if diags.HasError() {
+			return c, fmt.Errorf("failed reading available releases: %s - %s", diags[0].Summary(), diags[0].Detail())
+		}
+		for _, r := range releases {
+			c.Releases = append(c.Releases, r.Slug.ValueString())
+		}
+	}
+
+	return c, nil
+}
+
+// SupportsSpace reports whether the Plan is available in the Space path.
+func (c Compatibility) SupportsSpace(path string) bool {
+	return slices.Contains(c.Spaces, path)
+}
+
+// SupportsRelease reports whether the Plan is available for the Release slug.
+func (c Compatibility) SupportsRelease(slug string) bool {
+	return slices.Contains(c.Releases, slug)
+}
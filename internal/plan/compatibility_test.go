@@ -0,0 +1,33 @@
+package plan_test
+
+import (
+	"context"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/plan"
+)
+
+func (s *PlanTestSuite) TestPlan_Compatibility() {
+	c, err := plan.NewCompatibility(context.Background(), bonsai.Plan{
+		Slug: "sandbox",
+		AvailableReleases: []bonsai.Release{
+			{Slug: "opensearch-2.6.0-mt"},
+			{Slug: "elasticsearch-7.10.2"},
+		},
+		AvailableSpaces: []bonsai.Space{
+			{Path: "omc/bonsai/us-east-1/common"},
+		},
+	})
+	s.NoError(err)
+
+	s.Equal("sandbox", c.Plan)
+	s.True(c.SupportsSpace("omc/bonsai/us-east-1/common"))
+	s.False(c.SupportsSpace("omc/bonsai/eu-west-1/common"))
+	s.True(c.SupportsRelease("elasticsearch-7.10.2"))
+	s.False(c.SupportsRelease("opensearch-1.0.0"))
+
+	empty, err := plan.NewCompatibility(context.Background(), bonsai.Plan{Slug: "empty"})
+	s.NoError(err)
+	s.Empty(empty.Spaces)
+	s.Empty(empty.Releases)
+}
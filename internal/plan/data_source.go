@@ -7,12 +7,14 @@ import (
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
-	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // dataSource is the data source implementation.
 type dataSource struct {
-	client *bonsai.PlanClient
+	catalog *catalog.Catalog
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -40,6 +42,8 @@ func (d *dataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *tfds.
 
 // Read refreshes the Terraform state with the latest data.
 func (d *dataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var state model
 
 	// Fetch requested Slug from context
@@ -56,7 +60,7 @@ func (d *dataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.
 		return
 	}
 
-	s, err := d.client.Plan.GetBySlug(ctx, state.Slug.ValueString())
+	s, err := d.catalog.Plan(ctx, state.Slug.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Bonsai Plan (%s) from the Bonsai API", state.Slug.ValueString()),
@@ -86,15 +90,15 @@ func (d *dataSource) Configure(_ context.Context, req tfds.ConfigureRequest, res
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Plan
+	d.catalog = data.Catalog
 }
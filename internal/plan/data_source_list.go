@@ -1,22 +1,54 @@
 package plan
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // listDataSourceModel maps the data source schema data.
 type listDataSourceModel struct {
+	Filter    *listFilterModel `tfsdk:"filter"`
+	SortBy    types.String     `tfsdk:"sort_by"`
+	SortOrder types.String     `tfsdk:"sort_order"`
+
 	Plans []model `tfsdk:"plans"`
 }
 
+// listFilterModel maps the data source's filter attribute.
+type listFilterModel struct {
+	MaxPriceInCents types.Int64  `tfsdk:"max_price_in_cents"`
+	SingleTenant    types.Bool   `tfsdk:"single_tenant"`
+	PrivateNetwork  types.Bool   `tfsdk:"private_network"`
+	SupportsRelease types.String `tfsdk:"supports_release"`
+	SupportsSpace   types.String `tfsdk:"supports_space"`
+}
+
+// listSortKeys are the sort_by values accepted by the data source.
+var listSortKeys = map[string]func(a, b bonsai.Plan) int{
+	"name": filter.ByString(func(p bonsai.Plan) string { return p.Name }),
+	"slug": filter.ByString(func(p bonsai.Plan) string { return p.Slug }),
+	"price": func(a, b bonsai.Plan) int {
+		return cmp.Compare(budget.MonthlyCostCents(a), budget.MonthlyCostCents(b))
+	},
+}
+
 // listDataSource is the data source implementation.
 type listDataSource struct {
-	client *bonsai.PlanClient
+	catalog *catalog.Catalog
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -39,6 +71,49 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 	resp.Schema = dschema.Schema{
 		MarkdownDescription: listDataSourceMarkdownDescription,
 		Attributes: map[string]dschema.Attribute{
+			"filter": dschema.SingleNestedAttribute{
+				MarkdownDescription: "Criteria which the listed plans must all match. " +
+					"Any plan matches criteria which aren't set.",
+				Optional: true,
+				Attributes: map[string]dschema.Attribute{
+					"max_price_in_cents": dschema.Int64Attribute{
+						MarkdownDescription: "The maximum price of the plan, in cents, per billing interval.",
+						Optional:            true,
+					},
+					"single_tenant": dschema.BoolAttribute{
+						MarkdownDescription: "Whether the plan is single-tenant.",
+						Optional:            true,
+					},
+					"private_network": dschema.BoolAttribute{
+						MarkdownDescription: "Whether the plan is on a private network.",
+						Optional:            true,
+					},
+					"supports_release": dschema.StringAttribute{
+						MarkdownDescription: "The slug of a release which the plan must be available for, " +
+							"such as `opensearch-2.6.0-mt`.",
+						Optional: true,
+					},
+					"supports_space": dschema.StringAttribute{
+						MarkdownDescription: "The path of a space which the plan must be available in, " +
+							"such as `omc/bonsai/us-east-1/common`.",
+						Optional: true,
+					},
+				},
+			},
+			"sort_by": dschema.StringAttribute{
+				MarkdownDescription: "The attribute to sort the listed plans by; one of `" +
+					strings.Join(filter.SortKeys(listSortKeys), "`, `") + "`. " +
+					"Sorting by `price` orders plans by their monthly cost, `price_in_cents` divided by " +
+					"`billing_interval_months`, such that the cheapest plan matching the filter is listed first. " +
+					"Plans are listed in the order returned by the Bonsai API if not set.",
+				Optional: true,
+			},
+			"sort_order": dschema.StringAttribute{
+				MarkdownDescription: "The order to sort the listed plans in; either `" +
+					filter.SortOrderAscending + "` or `" + filter.SortOrderDescending + "`. " +
+					"Defaults to `" + filter.SortOrderAscending + "`.",
+				Optional: true,
+			},
 			"plans": dschema.ListNestedAttribute{
 				MarkdownDescription: dataSourceMarkdownDescription,
 				Computed:            true,
@@ -52,9 +127,22 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 
 // Read refreshes the Terraform state with the latest data.
 func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var state listDataSourceModel
 
-	plans, err := d.client.Plan.All(ctx)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(filter.OneOf(state.SortBy, path.Root("sort_by"), filter.SortKeys(listSortKeys)...)...)
+	resp.Diagnostics.Append(filter.OneOf(state.SortOrder, path.Root("sort_order"), filter.SortOrderAscending, filter.SortOrderDescending)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plans, err := d.catalog.Plans(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Bonsai Plans from the Bonsai API",
@@ -63,7 +151,11 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 		return
 	}
 
+	plans = slices.DeleteFunc(plans, func(p bonsai.Plan) bool { return !state.matches(p) })
+	filter.Sort(plans, state.SortBy, state.SortOrder, listSortKeys)
+
 	// Map response body to model
+	state.Plans = make([]model, 0, len(plans))
 	for _, r := range plans {
 		planState, err := convert(ctx, r)
 		if err != nil {
@@ -81,21 +173,47 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 	resp.Diagnostics.Append(diags...)
 }
 
+// matches reports whether the Plan matches the data source's filter.
+func (m listDataSourceModel) matches(p bonsai.Plan) bool {
+	if m.Filter == nil {
+		return true
+	}
+
+	f := *m.Filter
+
+	supportsRelease := f.SupportsRelease.IsNull() || f.SupportsRelease.IsUnknown() ||
+		slices.ContainsFunc(p.AvailableReleases, func(r bonsai.Release) bool {
+			return r.Slug == f.SupportsRelease.ValueString()
+		})
+
+	supportsSpace := f.SupportsSpace.IsNull() || f.SupportsSpace.IsUnknown() ||
+		slices.ContainsFunc(p.AvailableSpaces, func(s bonsai.Space) bool {
+			return s.Path == f.SupportsSpace.ValueString()
+		})
+
+	// Plans which don't state their tenancy or networking have neither.
+	return filter.AtMost(f.MaxPriceInCents, p.PriceInCents) &&
+		filter.EqualBool(f.SingleTenant, p.SingleTenant != nil && *p.SingleTenant) &&
+		filter.EqualBool(f.PrivateNetwork, p.PrivateNetwork != nil && *p.PrivateNetwork) &&
+		supportsRelease &&
+		supportsSpace
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *listDataSource) Configure(_ context.Context, req tfds.ConfigureRequest, resp *tfds.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Plan
+	d.catalog = data.Catalog
 }
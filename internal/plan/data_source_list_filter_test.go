@@ -0,0 +1,122 @@
+package plan_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/plan"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListDataSourceFilterTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestListDataSourceFilterTestSuite(t *testing.T) {
+	suite.Run(t, &ListDataSourceFilterTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *ListDataSourceFilterTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "standard-sm",
+						"name": "Standard Small",
+						"price_in_cents": 5000,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt", "elasticsearch-7.10.2"],
+						"available_spaces": ["omc/bonsai/us-east-1/common", "omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "sandbox",
+						"name": "Sandbox",
+						"price_in_cents": 0,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					},
+					{
+						"slug": "standard-sm-annual",
+						"name": "Standard Small (Annual)",
+						"price_in_cents": 54000,
+						"billing_interval_in_months": 12,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["elasticsearch-7.10.2"],
+						"available_spaces": ["omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "dedicated-lg",
+						"name": "Dedicated Large",
+						"price_in_cents": 90000,
+						"billing_interval_in_months": 1,
+						"single_tenant": true,
+						"private_network": true,
+						"available_releases": ["opensearch-2.6.0-mt", "elasticsearch-7.10.2"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					}
+				]
+			}
+		`))
+	})
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_NoFilter() {
+	s.Equal([]string{"standard-sm", "sandbox", "standard-sm-annual", "dedicated-lg"}, s.Listed(s.Read(plan.NewListDataSource(), nil), path.Root("plans"), "slug"))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_Filter() {
+	s.Equal([]string{"standard-sm", "sandbox"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.max_price_in_cents": int64(5000),
+	}), path.Root("plans"), "slug"))
+
+	s.Equal([]string{"dedicated-lg"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.single_tenant":   true,
+		"filter.private_network": true,
+	}), path.Root("plans"), "slug"))
+
+	s.Equal([]string{"standard-sm", "standard-sm-annual", "dedicated-lg"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.supports_release": "elasticsearch-7.10.2",
+	}), path.Root("plans"), "slug"))
+
+	s.Equal([]string{"standard-sm", "standard-sm-annual"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.supports_space":   "omc/bonsai/eu-west-1/common",
+		"filter.supports_release": "elasticsearch-7.10.2",
+	}), path.Root("plans"), "slug"))
+
+	s.Empty(s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.supports_space": "omc/bonsai/ap-southeast-2/common",
+	}), path.Root("plans"), "slug"))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_SortByPrice() {
+	// The cheapest plan satisfying the requirements is listed first; the
+	// annual plan costs 4500 cents a month, less than the monthly plan.
+	s.Equal([]string{"standard-sm-annual", "standard-sm", "dedicated-lg"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"filter.supports_release": "elasticsearch-7.10.2",
+		"sort_by":                 "price",
+	}), path.Root("plans"), "slug"))
+
+	s.Equal([]string{"dedicated-lg", "standard-sm", "standard-sm-annual", "sandbox"}, s.Listed(s.Read(plan.NewListDataSource(), map[string]any{
+		"sort_by":    "price",
+		"sort_order": "desc",
+	}), path.Root("plans"), "slug"))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_InvalidSort() {
+	resp := s.Read(plan.NewListDataSource(), map[string]any{"sort_by": "popularity"})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), `got "popularity"`)
+}
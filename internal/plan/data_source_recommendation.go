@@ -0,0 +1,311 @@
+package plan
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+	"github.com/omc/terraform-provider-bonsai/internal/release"
+	"github.com/omc/terraform-provider-bonsai/internal/space"
+)
+
+const recommendationDataSourceMarkdownDescription = "Recommends the cheapest " +
+	"plan available to your account which meets the given requirements, along " +
+	"with the alternatives, ranked by monthly cost. Any plan meets requirements " +
+	"which aren't set, though a plan must be available in at least one space " +
+	"and for at least one release meeting them. As the Plans API doesn't publish the " +
+	"capacity of plans, requirements such as the expected number of documents " +
+	"or shards aren't accepted."
+
+// recommendationDataSourceModel maps the data source schema data.
+type recommendationDataSourceModel struct {
+	ServiceType       types.String   `tfsdk:"service_type"`
+	VersionConstraint types.String   `tfsdk:"version_constraint"`
+	CloudProvider     types.String   `tfsdk:"cloud_provider"`
+	Region            []types.String `tfsdk:"region"`
+	PrivateNetwork    types.Bool     `tfsdk:"private_network"`
+	SingleTenant      types.Bool     `tfsdk:"single_tenant"`
+	MaxPriceInCents   types.Int64    `tfsdk:"max_price_in_cents"`
+
+	Recommended  *recommendationModel  `tfsdk:"recommended"`
+	Alternatives []recommendationModel `tfsdk:"alternatives"`
+}
+
+// recommendationModel maps a recommended plan, with the spaces and releases
+// meeting the requirements which it's available for.
+type recommendationModel struct {
+	Plan         model    `tfsdk:"plan"`
+	SpacePaths   []string `tfsdk:"space_paths"`
+	ReleaseSlugs []string `tfsdk:"release_slugs"`
+}
+
+// recommendationDataSource is the data source implementation.
+type recommendationDataSource struct {
+	catalog *catalog.Catalog
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ tfds.DataSource = &recommendationDataSource{}
+)
+
+// NewRecommendationDataSource is a helper function to simplify the provider implementation.
+func NewRecommendationDataSource() tfds.DataSource {
+	return &recommendationDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *recommendationDataSource) Metadata(_ context.Context, req tfds.MetadataRequest, resp *tfds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plan_recommendation"
+}
+
+// Schema defines the schema for the data source.
+func (d *recommendationDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *tfds.SchemaResponse) {
+	recommendationAttributes := map[string]dschema.Attribute{
+		"plan": dschema.SingleNestedAttribute{
+			MarkdownDescription: dataSourceMarkdownDescription,
+			Computed:            true,
+			Attributes:          schemaAttributes(),
+		},
+		"space_paths": dschema.ListAttribute{
+			MarkdownDescription: "The paths of the spaces meeting the requirements " +
+				"which the plan is available in.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"release_slugs": dschema.ListAttribute{
+			MarkdownDescription: "The slugs of the releases meeting the requirements " +
+				"which the plan is available for.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+	}
+
+	resp.Schema = dschema.Schema{
+		MarkdownDescription: recommendationDataSourceMarkdownDescription,
+		Attributes: map[string]dschema.Attribute{
+			"service_type": dschema.StringAttribute{
+				MarkdownDescription: "The service type of the release, such as " +
+					"`opensearch` or `elasticsearch`. Matched case-insensitively.",
+				Optional: true,
+			},
+			"version_constraint": dschema.StringAttribute{
+				MarkdownDescription: "A constraint which the version of the release " +
+					"must satisfy, such as `~> 2.6` or `>= 7.10, < 8`.",
+				Optional: true,
+			},
+			"cloud_provider": dschema.StringAttribute{
+				MarkdownDescription: "The cloud provider of the space, such as `aws`. " +
+					"Matched case-insensitively.",
+				Optional: true,
+			},
+			"region": dschema.ListAttribute{
+				MarkdownDescription: "The regions which the space may be in, such as " +
+					"`aws-us-east-1`. Each may be a glob pattern, in which `*` matches " +
+					"any sequence of characters and `?` any single character, such as `aws-us-*`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"private_network": dschema.BoolAttribute{
+				MarkdownDescription: "Whether the plan is on a private network.",
+				Optional:            true,
+			},
+			"single_tenant": dschema.BoolAttribute{
+				MarkdownDescription: "Whether the plan is single-tenant.",
+				Optional:            true,
+			},
+			"max_price_in_cents": dschema.Int64Attribute{
+				MarkdownDescription: "The maximum price of the plan, in cents, per billing interval.",
+				Optional:            true,
+			},
+			"recommended": dschema.SingleNestedAttribute{
+				MarkdownDescription: "The plan meeting the requirements with the lowest monthly cost.",
+				Computed:            true,
+				Attributes:          recommendationAttributes,
+			},
+			"alternatives": dschema.ListNestedAttribute{
+				MarkdownDescription: "The other plans meeting the requirements, " +
+					"ordered by monthly cost, then slug.",
+				Computed: true,
+				NestedObject: dschema.NestedAttributeObject{
+					Attributes: recommendationAttributes,
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recommendationDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
+	var state recommendationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	constraints, err := release.ParseConstraint(state.VersionConstraint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version_constraint"),
+			"Invalid Version Constraint",
+			err.Error(),
+		)
+		return
+	}
+
+	plans, err := d.catalog.Plans(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bonsai Plans from the Bonsai API",
+			err.Error(),
+		)
+		return
+	}
+
+	spaces, err := d.catalog.Spaces(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bonsai Spaces from the Bonsai API",
+			err.Error(),
+		)
+		return
+	}
+
+	releases, err := d.catalog.Releases(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bonsai Releases from the Bonsai API",
+			err.Error(),
+		)
+		return
+	}
+
+	spacesByPath := make(map[string]bonsai.Space, len(spaces))
+	for _, s := range spaces {
+		spacesByPath[s.Path] = s
+	}
+
+	releasesBySlug := make(map[string]bonsai.Release, len(releases))
+	for _, r := range releases {
+		releasesBySlug[r.Slug] = r
+	}
+
+	plans = slices.DeleteFunc(plans, func(p bonsai.Plan) bool { return !state.matches(p) })
+	slices.SortStableFunc(plans, func(a, b bonsai.Plan) int {
+		return cmp.Or(cmp.Compare(budget.MonthlyCostCents(a), budget.MonthlyCostCents(b)), strings.Compare(a.Slug, b.Slug))
+	})
+
+	// Map response body to model
+	recommendations := make([]recommendationModel, 0, len(plans))
+	for _, p := range plans {
+		// Spaces and releases missing from the catalog are known only by
+		// their path or slug, such that they only meet requirements which
+		// aren't set, or which their slug's version satisfies.
+		var spacePaths []string
+		for _, s := range p.AvailableSpaces {
+			if known, ok := spacesByPath[s.Path]; ok {
+				s = known
+			}
+			if state.matchesSpace(s) {
+				spacePaths = append(spacePaths, s.Path)
+			}
+		}
+
+		available := make([]bonsai.Release, len(p.AvailableReleases))
+		for i, r := range p.AvailableReleases {
+			available[i] = r
+			if known, ok := releasesBySlug[r.Slug]; ok {
+				available[i] = known
+			}
+		}
+
+		var releaseSlugs []string
+		for _, r := range release.Select(available, state.ServiceType, constraints) {
+			releaseSlugs = append(releaseSlugs, r.Slug)
+		}
+
+		if len(spacePaths) == 0 || len(releaseSlugs) == 0 {
+			continue
+		}
+
+		planState, err := convert(ctx, p)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"unable to Convert Bonsai Plan from the Bonsai API",
+				err.Error(),
+			)
+			return
+		}
+
+		recommendations = append(recommendations, recommendationModel{
+			Plan:         planState,
+			SpacePaths:   spacePaths,
+			ReleaseSlugs: releaseSlugs,
+		})
+	}
+
+	if len(recommendations) == 0 {
+		resp.Diagnostics.AddError(
+			"No Bonsai Plan Meets the Requirements",
+			"None of the plans available to your account meet the requirements, "+
+				"in any space or for any release meeting them. Consider relaxing the requirements, "+
+				"such as by raising max_price_in_cents or by adding regions.",
+		)
+		return
+	}
+
+	state.Recommended = &recommendations[0]
+	state.Alternatives = recommendations[1:]
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// matches reports whether the Plan meets the data source's plan requirements.
+func (m recommendationDataSourceModel) matches(p bonsai.Plan) bool {
+	// Plans which don't state their tenancy or networking have neither.
+	return filter.AtMost(m.MaxPriceInCents, p.PriceInCents) &&
+		filter.EqualBool(m.SingleTenant, p.SingleTenant != nil && *p.SingleTenant) &&
+		filter.EqualBool(m.PrivateNetwork, p.PrivateNetwork != nil && *p.PrivateNetwork)
+}
+
+// matchesSpace reports whether the Space meets the data source's space
+// requirements.
+func (m recommendationDataSourceModel) matchesSpace(s bonsai.Space) bool {
+	return space.MatchesCloud(s, m.CloudProvider, m.Region)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recommendationDataSource) Configure(_ context.Context, req tfds.ConfigureRequest, resp *tfds.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.Data)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.catalog = data.Catalog
+}
@@ -0,0 +1,208 @@
+package plan_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/plan"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecommendationDataSourceTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestRecommendationDataSourceTestSuite(t *testing.T) {
+	suite.Run(t, &RecommendationDataSourceTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *RecommendationDataSourceTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "dedicated-lg",
+						"name": "Dedicated Large",
+						"price_in_cents": 90000,
+						"billing_interval_in_months": 1,
+						"single_tenant": true,
+						"private_network": true,
+						"available_releases": ["opensearch-2.11.1", "elasticsearch-7.10.2"],
+						"available_spaces": ["omc/bonsai/us-east-1/acme-vpc"]
+					},
+					{
+						"slug": "standard-sm",
+						"name": "Standard Small",
+						"price_in_cents": 5000,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt", "elasticsearch-7.10.2"],
+						"available_spaces": ["omc/bonsai/us-east-1/common", "omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "standard-md",
+						"name": "Standard Medium",
+						"price_in_cents": 5000,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.11.1"],
+						"available_spaces": ["omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "standard-md-annual",
+						"name": "Standard Medium (Annual)",
+						"price_in_cents": 54000,
+						"billing_interval_in_months": 12,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.11.1"],
+						"available_spaces": ["omc/bonsai/eu-west-1/common"]
+					},
+					{
+						"slug": "sandbox",
+						"name": "Sandbox",
+						"price_in_cents": 0,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common", "omc/bonsai/ap-southeast-2/common"]
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.SpaceAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"spaces": [
+					{
+						"path": "omc/bonsai/us-east-1/common",
+						"private_network": false,
+						"cloud": {"provider": "aws", "region": "aws-us-east-1"}
+					},
+					{
+						"path": "omc/bonsai/eu-west-1/common",
+						"private_network": false,
+						"cloud": {"provider": "aws", "region": "aws-eu-west-1"}
+					},
+					{
+						"path": "omc/bonsai/us-east-1/acme-vpc",
+						"private_network": true,
+						"cloud": {"provider": "aws", "region": "aws-us-east-1"}
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ReleaseAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"releases": [
+					{"slug": "elasticsearch-7.10.2", "service_type": "elasticsearch", "version": "7.10.2"},
+					{"slug": "opensearch-2.6.0-mt", "service_type": "opensearch", "version": "2.6.0"},
+					{"slug": "opensearch-2.11.1", "service_type": "opensearch", "version": "2.11.1"}
+				]
+			}
+		`))
+	})
+}
+
+func (s *RecommendationDataSourceTestSuite) TestRecommendationDataSource_NoRequirements() {
+	resp := s.Read(plan.NewRecommendationDataSource(), nil)
+
+	// Plans are ranked by their monthly cost, such that the annual plan's
+	// 4500 cents a month ranks below the sandbox plan, but above the monthly
+	// plans of 5000 cents, which are ordered by slug.
+	s.Equal("sandbox", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Equal([]string{"standard-md-annual", "standard-md", "standard-sm", "dedicated-lg"}, s.Listed(resp, path.Root("alternatives"), "plan", "slug"))
+
+	// The sandbox plan's Asia Pacific space is missing from the spaces
+	// listing, and so known only by its path.
+	s.Equal([]string{"omc/bonsai/us-east-1/common", "omc/bonsai/ap-southeast-2/common"}, s.Strings(resp, path.Root("recommended").AtName("space_paths")))
+	s.Equal([]string{"opensearch-2.6.0-mt"}, s.Strings(resp, path.Root("recommended").AtName("release_slugs")))
+}
+
+func (s *RecommendationDataSourceTestSuite) TestRecommendationDataSource_SpaceMissingFromCatalog() {
+	// Spaces known only by their path don't meet cloud requirements.
+	resp := s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"cloud_provider": "aws",
+	})
+	s.Equal("sandbox", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Equal([]string{"omc/bonsai/us-east-1/common"}, s.Strings(resp, path.Root("recommended").AtName("space_paths")))
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"region": []string{"*"},
+	})
+	s.Equal([]string{"omc/bonsai/us-east-1/common"}, s.Strings(resp, path.Root("recommended").AtName("space_paths")))
+}
+
+func (s *RecommendationDataSourceTestSuite) TestRecommendationDataSource_Requirements() {
+	resp := s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"region": []string{"aws-eu-*"},
+	})
+	s.Equal("standard-md-annual", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Equal([]string{"standard-md", "standard-sm"}, s.Listed(resp, path.Root("alternatives"), "plan", "slug"))
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"service_type": "Elasticsearch",
+	})
+	s.Equal("standard-sm", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Equal([]string{"dedicated-lg"}, s.Listed(resp, path.Root("alternatives"), "plan", "slug"))
+	s.Equal([]string{"omc/bonsai/us-east-1/common", "omc/bonsai/eu-west-1/common"}, s.Strings(resp, path.Root("recommended").AtName("space_paths")))
+	s.Equal([]string{"elasticsearch-7.10.2"}, s.Strings(resp, path.Root("recommended").AtName("release_slugs")))
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"cloud_provider":     "aws",
+		"region":             []string{"aws-us-east-1"},
+		"service_type":       "opensearch",
+		"version_constraint": "~> 2.11",
+	})
+	s.Equal("dedicated-lg", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Empty(s.Listed(resp, path.Root("alternatives"), "plan", "slug"))
+	s.Equal([]string{"omc/bonsai/us-east-1/acme-vpc"}, s.Strings(resp, path.Root("recommended").AtName("space_paths")))
+	s.Equal([]string{"opensearch-2.11.1"}, s.Strings(resp, path.Root("recommended").AtName("release_slugs")))
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"single_tenant":      false,
+		"private_network":    false,
+		"max_price_in_cents": int64(5000),
+		"version_constraint": ">= 2.6",
+	})
+	s.Equal("sandbox", s.String(resp, path.Root("recommended").AtName("plan").AtName("slug")))
+	s.Equal([]string{"standard-md", "standard-sm"}, s.Listed(resp, path.Root("alternatives"), "plan", "slug"))
+}
+
+func (s *RecommendationDataSourceTestSuite) TestRecommendationDataSource_Errors() {
+	resp := s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"single_tenant":      true,
+		"max_price_in_cents": int64(50000),
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("No Bonsai Plan Meets the Requirements", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"cloud_provider": "gcp",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("No Bonsai Plan Meets the Requirements", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(plan.NewRecommendationDataSource(), map[string]any{
+		"version_constraint": "newest",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Invalid Version Constraint", resp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,137 @@
+// Package policy implements the provider-level guardrails restricting the
+// Clusters which may be created or updated.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// Attributes of a Cluster which a Policy may restrict.
+const (
+	AttributePlan    = "plan"
+	AttributeSpace   = "space"
+	AttributeRelease = "release"
+)
+
+// Policy restricts the Plans, Spaces and Releases of Clusters.
+//
+// A nil list permits any value, while an empty, non-nil list permits none.
+type Policy struct {
+	// AllowedPlans lists the permitted Plan slugs.
+	AllowedPlans []string
+	// AllowedSpaces lists glob patterns of the permitted Space paths, where
+	// "*" matches any sequence of characters, including "/", and "?"
+	// matches any single character.
+	AllowedSpaces []string
+	// AllowedReleases lists the permitted Release slugs.
+	AllowedReleases []string
+}
+
+// Violation describes a Cluster attribute value which isn't permitted by a
+// Policy.
+type Violation struct {
+	// Attribute is the restricted attribute; one of AttributePlan,
+	// AttributeSpace or AttributeRelease.
+	Attribute string
+	// Value is the attribute's value.
+	Value string
+	// Allowed lists the values, or patterns, permitted by the Policy.
+	Allowed []string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s %q is not permitted by the allowed_%ss policy", v.Attribute, v.Value, v.Attribute)
+}
+
+// IsZero reports whether p permits every Cluster.
+func (p Policy) IsZero() bool {
+	return p.AllowedPlans == nil && p.AllowedSpaces == nil && p.AllowedReleases == nil
+}
+
+// Allowed returns the values, or patterns, permitted for the attribute; one
+// of AttributePlan, AttributeSpace or AttributeRelease.
+func (p Policy) Allowed(attribute string) []string {
+	switch attribute {
+	case AttributePlan:
+		return p.AllowedPlans
+	case AttributeSpace:
+		return p.AllowedSpaces
+	case AttributeRelease:
+		return p.AllowedReleases
+	default:
+		return nil
+	}
+}
+
+// Restricts reports whether p restricts the attribute.
+func (p Policy) Restricts(attribute string) bool {
+	return p.Allowed(attribute) != nil
+}
+
+// AllowsPlan reports whether p permits the Plan slug.
+func (p Policy) AllowsPlan(slug string) bool {
+	return p.AllowedPlans == nil || slices.Contains(p.AllowedPlans, slug)
+}
+
+// AllowsSpace reports whether p permits the Space path.
+func (p Policy) AllowsSpace(path string) bool {
+	if p.AllowedSpaces == nil {
+		return true
+	}
+
+	return slices.ContainsFunc(p.AllowedSpaces, func(pattern string) bool {
+		return MatchGlob(pattern, path)
+	})
+}
+
+// AllowsRelease reports whether p permits the Release slug.
+func (p Policy) AllowsRelease(slug string) bool {
+	return p.AllowedReleases == nil || slices.Contains(p.AllowedReleases, slug)
+}
+
+// Check returns the violations of p by the Cluster options. Empty options
+// aren't checked, such that options which aren't yet known may be omitted.
+func (p Policy) Check(opts bonsai.ClusterCreateOpts) []Violation {
+	var violations []Violation
+
+	if opts.Plan != "" && !p.AllowsPlan(opts.Plan) {
+		violations = append(violations, Violation{Attribute: AttributePlan, Value: opts.Plan, Allowed: p.AllowedPlans})
+	}
+
+	if opts.Space != "" && !p.AllowsSpace(opts.Space) {
+		violations = append(violations, Violation{Attribute: AttributeSpace, Value: opts.Space, Allowed: p.AllowedSpaces})
+	}
+
+	if opts.Release != "" && !p.AllowsRelease(opts.Release) {
+		violations = append(violations, Violation{Attribute: AttributeRelease, Value: opts.Release, Allowed: p.AllowedReleases})
+	}
+
+	return violations
+}
+
+// MatchGlob reports whether s matches the glob pattern, where "*" matches
+// any sequence of characters, including "/", and "?" matches any single
+// character.
+func MatchGlob(pattern, s string) bool {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(s)
+}
@@ -0,0 +1,86 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PolicyTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+func (s *PolicyTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *PolicyTestSuite) TestMatchGlob() {
+	s.True(policy.MatchGlob("omc/bonsai/us-east-1/common", "omc/bonsai/us-east-1/common"))
+	s.True(policy.MatchGlob("omc/bonsai/us-*", "omc/bonsai/us-east-1/common"))
+	s.True(policy.MatchGlob("omc/bonsai/*/common", "omc/bonsai/eu-west-1/common"))
+	s.True(policy.MatchGlob("omc/bonsai/us-east-?/common", "omc/bonsai/us-east-1/common"))
+	s.False(policy.MatchGlob("omc/bonsai/us-*", "omc/bonsai/eu-west-1/common"))
+	s.False(policy.MatchGlob("omc/bonsai/us-east-1", "omc/bonsai/us-east-1/common"))
+	s.False(policy.MatchGlob("omc/bonsai/us-east-1.common", "omc/bonsai/us-east-1/common"))
+}
+
+func (s *PolicyTestSuite) TestPolicy_ZeroPermitsAll() {
+	p := policy.Policy{}
+
+	s.True(p.IsZero())
+	s.Empty(p.Check(bonsai.ClusterCreateOpts{
+		Plan:    "standard-sm",
+		Space:   "omc/bonsai/us-east-1/common",
+		Release: "opensearch-2.6.0-mt",
+	}))
+}
+
+func (s *PolicyTestSuite) TestPolicy_EmptyPermitsNone() {
+	p := policy.Policy{AllowedPlans: []string{}}
+
+	s.False(p.IsZero())
+	s.False(p.AllowsPlan("sandbox"))
+	s.True(p.AllowsSpace("omc/bonsai/us-east-1/common"))
+	s.True(p.Restricts(policy.AttributePlan))
+	s.False(p.Restricts(policy.AttributeSpace))
+	s.False(p.Restricts(policy.AttributeRelease))
+	s.Equal([]string{}, p.Allowed(policy.AttributePlan))
+	s.Nil(p.Allowed(policy.AttributeSpace))
+}
+
+func (s *PolicyTestSuite) TestPolicy_Check() {
+	p := policy.Policy{
+		AllowedPlans:    []string{"sandbox", "standard-sm"},
+		AllowedSpaces:   []string{"omc/bonsai/us-*"},
+		AllowedReleases: []string{"opensearch-2.6.0-mt"},
+	}
+
+	s.Empty(p.Check(bonsai.ClusterCreateOpts{
+		Plan:    "standard-sm",
+		Space:   "omc/bonsai/us-east-1/common",
+		Release: "opensearch-2.6.0-mt",
+	}))
+
+	violations := p.Check(bonsai.ClusterCreateOpts{
+		Plan:    "enterprise-lg",
+		Space:   "omc/bonsai/eu-west-1/common",
+		Release: "elasticsearch-7.10.2",
+	})
+	s.Equal([]policy.Violation{
+		{Attribute: policy.AttributePlan, Value: "enterprise-lg", Allowed: p.AllowedPlans},
+		{Attribute: policy.AttributeSpace, Value: "omc/bonsai/eu-west-1/common", Allowed: p.AllowedSpaces},
+		{Attribute: policy.AttributeRelease, Value: "elasticsearch-7.10.2", Allowed: p.AllowedReleases},
+	}, violations)
+	s.EqualError(violations[1], `space "omc/bonsai/eu-west-1/common" is not permitted by the allowed_spaces policy`)
+
+	// Options which aren't yet known are omitted.
+	s.Empty(p.Check(bonsai.ClusterCreateOpts{Plan: "sandbox"}))
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+)
+
+// catalogSchemaAttributes defines the provider's catalog cache attributes.
+func catalogSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"catalog_cache_ttl": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "Duration for which the plans, spaces and releases listed by the Bonsai API " +
+				"are cached, and shared by all data sources and plan-time validation, such as `\"10m\"`. " +
+				fmt.Sprintf("Defaults to `\"%s\"`.", catalog.DefaultTTL),
+		},
+		"disable_catalog_cache": schema.BoolAttribute{
+			Optional: true,
+			MarkdownDescription: "Whether to disable the catalog cache, such that every data source " +
+				"and plan-time validation requests plans, spaces and releases from the Bonsai API. " +
+				"Defaults to `false`.",
+		},
+	}
+}
+
+// newCatalog builds the catalog of plans, spaces and releases shared by the
+// provider's data sources and resources, according to the provider
+// configuration.
+func newCatalog(config bonsaiProviderModel, client *bonsai.Client) (*catalog.Catalog, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.CatalogCacheTTL.IsUnknown() || config.DisableCatalogCache.IsUnknown() {
+		diags.AddError(
+			"Unknown Bonsai Catalog Cache Configuration",
+			"The provider cannot configure its catalog cache as there is an unknown configuration value for "+
+				"catalog_cache_ttl or disable_catalog_cache. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	if config.DisableCatalogCache.ValueBool() {
+		return catalog.New(client, 0), diags
+	}
+
+	ttl := catalog.DefaultTTL
+	if !config.CatalogCacheTTL.IsNull() {
+		ttl = parseDuration(config.CatalogCacheTTL, path.Root("catalog_cache_ttl"), &diags)
+	}
+
+	return catalog.New(client, ttl), diags
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialProcessTimeout bounds the run time of a credential process.
+const credentialProcessTimeout = 1 * time.Minute
+
+// credentialProcessOutput is the JSON document a credential process must
+// write to its standard output.
+type credentialProcessOutput struct {
+	APIKey   string `json:"api_key"`
+	APIToken string `json:"api_token"`
+	// Expiration is the optional RFC 3339 time after which the credentials
+	// are no longer valid.
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// credentialProcessError describes a credential process failure, retaining
+// the standard error output of the process for diagnostics.
+type credentialProcessError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *credentialProcessError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s\n\nProcess standard error:\n%s", e.Err, e.Stderr)
+}
+
+func (e *credentialProcessError) Unwrap() error {
+	return e.Err
+}
+
+// runCredentialProcess runs command through the system shell, returning the
+// credentials it writes to standard output.
+func runCredentialProcess(ctx context.Context, command string) (credentialProcessOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s: %w", credentialProcessTimeout, ctx.Err())
+		}
+		return credentialProcessOutput{}, &credentialProcessError{
+			Err:    fmt.Errorf("credential process failed: %w", err),
+			Stderr: strings.TrimSpace(stderr.String()),
+		}
+	}
+
+	var out credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return credentialProcessOutput{}, &credentialProcessError{
+			Err:    fmt.Errorf("credential process output is not a valid JSON document: %w", err),
+			Stderr: strings.TrimSpace(stderr.String()),
+		}
+	}
+
+	var errs []error
+	if out.APIKey == "" {
+		errs = append(errs, errors.New("credential process output is missing api_key"))
+	}
+
+	if out.APIToken == "" {
+		errs = append(errs, errors.New("credential process output is missing api_token"))
+	}
+
+	if out.Expiration != nil && !out.Expiration.After(time.Now()) {
+		errs = append(errs, fmt.Errorf("credential process returned credentials which expired at %s", out.Expiration.Format(time.RFC3339)))
+	}
+
+	if len(errs) > 0 {
+		return credentialProcessOutput{}, &credentialProcessError{
+			Err:    errors.Join(errs...),
+			Stderr: strings.TrimSpace(stderr.String()),
+		}
+	}
+
+	return out, nil
+}
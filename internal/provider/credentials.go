@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+)
+
+// Credential sources, in order of precedence.
+const (
+	credentialSourceConfig  = "provider configuration"
+	credentialSourceProcess = "credential_process"
+	credentialSourceEnv     = "environment variable"
+)
+
+// credentials are the Bonsai API credentials used by the provider, alongside
+// descriptions of where each was read from.
+type credentials struct {
+	APIKey      string
+	APIToken    string
+	KeySource   string
+	TokenSource string
+}
+
+// Source describes where the credentials were read from, for diagnostics.
+func (c credentials) Source() string {
+	if c.KeySource == c.TokenSource {
+		return c.KeySource
+	}
+
+	return fmt.Sprintf("API key from %s, API token from %s", c.KeySource, c.TokenSource)
+}
+
+// resolveCredentials determines the Bonsai API credentials to use, taking
+// each of the API key and token from the first of the following sources to
+// provide it:
+//
+//  1. The api_key and api_token provider configuration attributes.
+//  2. The output of the credential_process provider configuration attribute.
+//  3. The BONSAI_API_KEY and BONSAI_API_TOKEN environment variables.
+//  4. The selected profile of the credentials file.
+func resolveCredentials(ctx context.Context, config bonsaiProviderModel) (credentials, diag.Diagnostics) {
+	var (
+		creds credentials
+		diags diag.Diagnostics
+	)
+
+	if !config.APIKey.IsNull() {
+		creds.APIKey, creds.KeySource = config.APIKey.ValueString(), credentialSourceConfig
+	}
+
+	if !config.APIToken.IsNull() {
+		creds.APIToken, creds.TokenSource = config.APIToken.ValueString(), credentialSourceConfig
+	}
+
+	if !config.CredentialProcess.IsNull() && (creds.KeySource == "" || creds.TokenSource == "") {
+		out, err := runCredentialProcess(ctx, config.CredentialProcess.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("credential_process"),
+				"Unable to Read Bonsai Credentials from Credential Process",
+				"The provider cannot read the Bonsai API credentials from the configured credential process. "+
+					"Ensure the command succeeds, writing a JSON document with api_key and api_token values to its standard output.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return creds, diags
+		}
+
+		if out.Expiration != nil {
+			tflog.Debug(ctx, "Credential process returned expiring credentials", map[string]interface{}{
+				"expiration": out.Expiration.Format(time.RFC3339),
+			})
+		}
+
+		if creds.KeySource == "" {
+			creds.APIKey, creds.KeySource = out.APIKey, credentialSourceProcess
+		}
+
+		if creds.TokenSource == "" {
+			creds.APIToken, creds.TokenSource = out.APIToken, credentialSourceProcess
+		}
+	}
+
+	if creds.KeySource == "" && os.Getenv("BONSAI_API_KEY") != "" {
+		creds.APIKey, creds.KeySource = os.Getenv("BONSAI_API_KEY"), "BONSAI_API_KEY "+credentialSourceEnv
+	}
+
+	if creds.TokenSource == "" && os.Getenv("BONSAI_API_TOKEN") != "" {
+		creds.APIToken, creds.TokenSource = os.Getenv("BONSAI_API_TOKEN"), "BONSAI_API_TOKEN "+credentialSourceEnv
+	}
+
+	// Higher precedence sources take priority over any selected profile.
+	if creds.KeySource != "" && creds.TokenSource != "" {
+		return creds, diags
+	}
+
+	profileName := os.Getenv("BONSAI_PROFILE")
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+	}
+
+	credentialsFile := os.Getenv("BONSAI_CREDENTIALS_FILE")
+	if !config.CredentialsFile.IsNull() {
+		credentialsFile = config.CredentialsFile.ValueString()
+	}
+
+	// A profile or credentials file which was asked for must be readable;
+	// otherwise, the default profile is used only if present.
+	explicit := profileName != "" || credentialsFile != ""
+
+	if profileName == "" {
+		profileName = defaultProfile
+	}
+
+	if credentialsFile == "" {
+		var err error
+		if credentialsFile, err = defaultCredentialsFile(); err != nil {
+			if explicit {
+				diags.AddAttributeError(
+					path.Root("credentials_file"),
+					"Unable to Locate Bonsai Credentials File",
+					"The provider cannot locate the default Bonsai credentials file. "+
+						"Set the credentials file path in the configuration or use the BONSAI_CREDENTIALS_FILE environment variable.\n\n"+
+						"Error: "+err.Error(),
+				)
+			}
+			return creds, diags
+		}
+	}
+
+	p, err := loadProfile(credentialsFile, profileName)
+	if err != nil {
+		if !explicit && (errors.Is(err, os.ErrNotExist) || errors.Is(err, errProfileNotFound)) {
+			return creds, diags
+		}
+
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unable to Read Bonsai Credentials Profile",
+			fmt.Sprintf("The provider cannot read profile %q from the Bonsai credentials file %s. ", profileName, credentialsFile)+
+				fmt.Sprintf("Ensure the file exists, is readable, and contains a [%s] section with api_key and api_token values.\n\n", profileName)+
+				"Error: "+err.Error(),
+		)
+		return creds, diags
+	}
+
+	profileSource := fmt.Sprintf("profile %q in %s", p.Name, credentialsFile)
+
+	if creds.KeySource == "" && p.APIKey != "" {
+		creds.APIKey, creds.KeySource = p.APIKey, profileSource
+	}
+
+	if creds.TokenSource == "" && p.APIToken != "" {
+		creds.APIToken, creds.TokenSource = p.APIToken, profileSource
+	}
+
+	return creds, diags
+}
+
+// verifyCredentials makes a single, lightweight authenticated request to the
+// Bonsai API, such that rejected credentials are reported once, naming their
+// source, rather than by each resource and data source.
+func verifyCredentials(ctx context.Context, plans *catalog.Catalog, creds credentials) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Verifying Bonsai API credentials")
+
+	_, err := plans.Plans(ctx)
+	switch {
+	case err == nil:
+		tflog.Debug(ctx, "Verified Bonsai API credentials")
+	case errors.Is(err, bonsai.ErrHTTPStatusUnauthorized), errors.Is(err, bonsai.ErrHTTPStatusForbidden):
+		diags.AddError(
+			"Invalid Bonsai API Credentials",
+			fmt.Sprintf("The Bonsai API rejected the provider's credentials, read from %s. ", creds.Source())+
+				"Ensure the API key and token are correct, belong to the same account, and have not been revoked. "+
+				"To skip this check, set verify_credentials to false.\n\n"+
+				"Error: "+err.Error(),
+		)
+	default:
+		diags.AddWarning(
+			"Unable to Verify Bonsai API Credentials",
+			fmt.Sprintf("The provider could not verify its credentials, read from %s, with the Bonsai API. ", creds.Source())+
+				"Requests made by resources and data sources may fail.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/transport"
+	"golang.org/x/time/rate"
+)
+
+// retryModel maps the provider's retry block to a Go type.
+type retryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
+}
+
+// retrySchemaBlock defines the provider's retry block.
+func retrySchemaBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Retry policy for requests to the Bonsai API failing transiently." + "\n\n" +
+			"Idempotent requests are retried after network errors, and `429`, `502`, `503` and `504` " +
+			"responses, while other requests are only retried after `429` responses. A response's " +
+			"`Retry-After` header is honoured when longer than the backoff.",
+		Attributes: map[string]schema.Attribute{
+			"max_attempts": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum number of attempts made per request, including the first. " +
+					fmt.Sprintf("Set to `1` to disable retries. Defaults to `%d`.", transport.DefaultMaxAttempts),
+			},
+			"min_backoff": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Duration to wait after the first failed attempt, doubling with each " +
+					fmt.Sprintf("attempt thereafter, such as `\"2s\"`. Defaults to `\"%s\"`.", transport.DefaultMinBackoff),
+			},
+			"max_backoff": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Maximum duration to wait between attempts, such as `\"1m\"`. " +
+					fmt.Sprintf("Defaults to `\"%s\"`.", transport.DefaultMaxBackoff),
+			},
+		},
+	}
+}
+
+// httpClientOptions configures the HTTP transport used by the Bonsai API
+// client, according to the provider configuration.
+func httpClientOptions(config bonsaiProviderModel) ([]bonsai.ClientOption, diag.Diagnostics) {
+	var (
+		diags   diag.Diagnostics
+		options []bonsai.ClientOption
+		base    http.RoundTripper
+	)
+
+	httpTransport, diags := newHTTPTransport(config)
+	if diags.HasError() {
+		return nil, diags
+	}
+	base = httpTransport
+
+	if !config.RequestTimeout.IsNull() {
+		base = &transport.Timeout{
+			Base:    base,
+			Timeout: parseDuration(config.RequestTimeout, path.Root("request_timeout"), &diags),
+		}
+	}
+
+	if config.MaxRequestsPerSecond.IsUnknown() || config.MaxConcurrentRequests.IsUnknown() {
+		diags.AddError(
+			"Unknown Bonsai API Request Limits",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for "+
+				"max_requests_per_second or max_concurrent_requests. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	if !config.MaxRequestsPerSecond.IsNull() && config.MaxRequestsPerSecond.ValueFloat64() <= 0 {
+		diags.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Invalid Bonsai API Request Limit",
+			fmt.Sprintf("The maximum number of requests per second must be positive, got %g.", config.MaxRequestsPerSecond.ValueFloat64()),
+		)
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && config.MaxConcurrentRequests.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Bonsai API Request Limit",
+			fmt.Sprintf("The maximum number of concurrent requests must be at least 1, got %d.", config.MaxConcurrentRequests.ValueInt64()),
+		)
+	}
+
+	if !config.MaxRequestsPerSecond.IsNull() || !config.MaxConcurrentRequests.IsNull() {
+		// Limits are shared by every resource and data source, as they share
+		// the provider's Bonsai API client.
+		base = transport.NewLimit(
+			base,
+			config.MaxRequestsPerSecond.ValueFloat64(),
+			int(config.MaxConcurrentRequests.ValueInt64()),
+		)
+	}
+
+	if !config.MaxRequestsPerSecond.IsNull() {
+		// The configured rate replaces the client's default rate limit,
+		// rather than compounding it.
+		options = append(options, bonsai.WithDefaultRateLimit(rate.NewLimiter(rate.Inf, 0)))
+	}
+
+	retry := &transport.Retry{
+		Base: base,
+	}
+
+	if config.Retry != nil {
+		if config.Retry.MaxAttempts.IsUnknown() || config.Retry.MinBackoff.IsUnknown() || config.Retry.MaxBackoff.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root("retry"),
+				"Unknown Bonsai API Retry Policy",
+				"The provider cannot create the Bonsai API client as there is an unknown configuration value for the retry policy. "+
+					"Either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+			return nil, diags
+		}
+
+		if !config.Retry.MaxAttempts.IsNull() {
+			if config.Retry.MaxAttempts.ValueInt64() < 1 {
+				diags.AddAttributeError(
+					path.Root("retry").AtName("max_attempts"),
+					"Invalid Bonsai API Retry Policy",
+					fmt.Sprintf("The maximum number of attempts must be at least 1, got %d.", config.Retry.MaxAttempts.ValueInt64()),
+				)
+			}
+			retry.MaxAttempts = int(config.Retry.MaxAttempts.ValueInt64())
+		}
+
+		retry.MinBackoff = parseDuration(config.Retry.MinBackoff, path.Root("retry").AtName("min_backoff"), &diags)
+		retry.MaxBackoff = parseDuration(config.Retry.MaxBackoff, path.Root("retry").AtName("max_backoff"), &diags)
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return append(options, bonsai.WithHTTPTransport(retry)), diags
+}
+
+// newHTTPTransport builds the transport used to connect to the Bonsai API,
+// applying the provider's proxy and TLS configuration.
+func newHTTPTransport(config bonsaiProviderModel) (*http.Transport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.HTTPProxy.IsUnknown() || config.CACertFile.IsUnknown() || config.CACertPEM.IsUnknown() ||
+		config.InsecureSkipVerify.IsUnknown() || config.RequestTimeout.IsUnknown() {
+		diags.AddError(
+			"Unknown Bonsai API HTTP Configuration",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for "+
+				"http_proxy, ca_cert_file, ca_cert_pem, insecure_skip_verify or request_timeout. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	//nolint:forcetypeassert // http.DefaultTransport is always an *http.Transport.
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if !config.HTTPProxy.IsNull() {
+		proxyURL, err := url.Parse(config.HTTPProxy.ValueString())
+		if err == nil && (proxyURL.Scheme == "" || proxyURL.Host == "") {
+			err = fmt.Errorf("expected an absolute URL, got %q", logging.RedactURL(config.HTTPProxy.ValueString()))
+		}
+
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("http_proxy"),
+				"Invalid HTTP Proxy",
+				"The HTTP proxy must be an absolute URL, such as http://proxy.example.com:3128.\n\n"+
+					"Error: "+err.Error(),
+			)
+		} else {
+			t.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	if !config.CACertFile.IsNull() || !config.CACertPEM.IsNull() {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !config.CACertFile.IsNull() {
+			pem, err := os.ReadFile(config.CACertFile.ValueString())
+			switch {
+			case err != nil:
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Unable to Read CA Certificate File",
+					"The provider cannot read the CA certificate bundle.\n\n"+
+						"Error: "+err.Error(),
+				)
+			case !pool.AppendCertsFromPEM(pem):
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid CA Certificate File",
+					fmt.Sprintf("The file %s contains no PEM encoded certificates.", config.CACertFile.ValueString()),
+				)
+			}
+		}
+
+		if !config.CACertPEM.IsNull() && !pool.AppendCertsFromPEM([]byte(config.CACertPEM.ValueString())) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid CA Certificate",
+				"The value contains no PEM encoded certificates.",
+			)
+		}
+
+		t.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		//nolint:gosec // Explicitly requested by the practitioner, and warned against.
+		t.TLSClientConfig.InsecureSkipVerify = true
+
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The provider will not verify the TLS certificate of the Bonsai API, or of any proxy. "+
+				"Connections, and the API credentials sent over them, are vulnerable to interception. "+
+				"Configure ca_cert_file or ca_cert_pem to trust an intercepting proxy instead, "+
+				"and never disable verification in production.",
+		)
+	}
+
+	return t, diags
+}
+
+// parseDuration parses a positive duration from value, if set, adding an
+// attribute error to diags should it be invalid.
+func parseDuration(value types.String, p path.Path, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("expected a positive duration, got %q", value.ValueString())
+	}
+
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			"The value must be a positive duration, such as \"30s\" or \"2m\".\n\n"+
+				"Error: "+err.Error(),
+		)
+		return 0
+	}
+
+	return d
+}
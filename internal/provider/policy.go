@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+)
+
+// policySchemaAttributes defines the provider's guardrail attributes.
+func policySchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"allowed_plans": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Slugs of the plans which `bonsai_cluster` resources may be created on, " +
+				"or updated to, which must then be known while planning. Any plan is permitted if not set.",
+		},
+		"allowed_spaces": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Glob patterns of the space paths which `bonsai_cluster` resources may be " +
+				"created in, such as `omc/bonsai/us-*`, where `*` matches any sequence of characters, " +
+				"including `/`, and `?` matches any single character. While set, each resource's space must be " +
+				"known while planning, rather than left to the default. Any space is permitted if not set.",
+		},
+		"allowed_releases": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Slugs of the releases which `bonsai_cluster` resources may be created " +
+				"with. While set, each resource's release must be known while planning, rather than left to " +
+				"the default. Any release is permitted if not set.",
+		},
+		"max_monthly_spend_cents": schema.Int64Attribute{
+			Optional: true,
+			MarkdownDescription: "Maximum monthly spend of the account's clusters, in cents. While planning, " +
+				"the monthly cost of each `bonsai_cluster` resource's plan, normalized by its billing interval, " +
+				"is summed with that of the other resources planned, and with the current monthly cost of the " +
+				"account's clusters which aren't managed by the configuration. The budget is only exceeded " +
+				"once the total also exceeds the account's current monthly spend. Unlimited if not set.",
+		},
+		"max_monthly_spend_enforcement": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "How exceeding `max_monthly_spend_cents` is reported; either `" +
+				budgetEnforcementError + "` to fail the plan, or `" + budgetEnforcementWarn + "` to only warn. " +
+				"Defaults to `" + budgetEnforcementError + "`.",
+		},
+		"read_only": schema.BoolAttribute{
+			Optional: true,
+			MarkdownDescription: "Whether to refuse to create, update or delete `bonsai_cluster` resources, " +
+				"such as for plans run with credentials which shouldn't modify the account. " +
+				"Such operations fail before any request is made to the Bonsai API, while data sources, " +
+				"and reading and importing resources, are unaffected. Defaults to `false`.",
+		},
+	}
+}
+
+// Values of the max_monthly_spend_enforcement attribute.
+const (
+	budgetEnforcementError = "error"
+	budgetEnforcementWarn  = "warn"
+)
+
+// newBudget builds the monthly spend budget enforced on bonsai_cluster
+// resources from the provider configuration, returning nil if none is set.
+func newBudget(config bonsaiProviderModel, client *bonsai.Client, plans *catalog.Catalog) (*budget.Budget, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.MaxMonthlySpendCents.IsUnknown() || config.MaxMonthlySpendEnforcement.IsUnknown() {
+		diags.AddError(
+			"Unknown Bonsai Monthly Spend Budget",
+			"The provider cannot enforce its monthly spend budget as there is an unknown configuration value for "+
+				"max_monthly_spend_cents or max_monthly_spend_enforcement. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	enforcement := budgetEnforcementError
+	if !config.MaxMonthlySpendEnforcement.IsNull() {
+		enforcement = config.MaxMonthlySpendEnforcement.ValueString()
+	}
+
+	if enforcement != budgetEnforcementError && enforcement != budgetEnforcementWarn {
+		diags.AddAttributeError(
+			path.Root("max_monthly_spend_enforcement"),
+			"Invalid Bonsai Monthly Spend Enforcement",
+			fmt.Sprintf("Expected %q or %q, got %q.", budgetEnforcementError, budgetEnforcementWarn, enforcement),
+		)
+	}
+
+	if config.MaxMonthlySpendCents.IsNull() {
+		return nil, diags
+	}
+
+	if config.MaxMonthlySpendCents.ValueInt64() < 0 {
+		diags.AddAttributeError(
+			path.Root("max_monthly_spend_cents"),
+			"Invalid Bonsai Monthly Spend Budget",
+			fmt.Sprintf("The monthly spend budget must not be negative, got %d.", config.MaxMonthlySpendCents.ValueInt64()),
+		)
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &budget.Budget{
+		MaxMonthlySpendCents: config.MaxMonthlySpendCents.ValueInt64(),
+		WarnOnly:             enforcement == budgetEnforcementWarn,
+		Clusters:             client.Cluster.All,
+		Plans:                plans.Plans,
+	}, diags
+}
+
+// newPolicy builds the guardrails enforced on bonsai_cluster resources from
+// the provider configuration.
+func newPolicy(ctx context.Context, config bonsaiProviderModel) (policy.Policy, diag.Diagnostics) {
+	var (
+		p     policy.Policy
+		diags diag.Diagnostics
+	)
+
+	p.AllowedPlans = allowedValues(ctx, config.AllowedPlans, "allowed_plans", &diags)
+	p.AllowedSpaces = allowedValues(ctx, config.AllowedSpaces, "allowed_spaces", &diags)
+	p.AllowedReleases = allowedValues(ctx, config.AllowedReleases, "allowed_releases", &diags)
+
+	return p, diags
+}
+
+// allowedValues reads the values of an allowed_* list attribute, returning
+// nil if it isn't set.
+func allowedValues(ctx context.Context, list types.List, attribute string, diags *diag.Diagnostics) []string {
+	if list.IsNull() {
+		return nil
+	}
+
+	if list.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Unknown Bonsai Cluster Policy",
+			"The provider cannot enforce its cluster policy as there is an unknown configuration value for "+attribute+". "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return nil
+	}
+
+	values := []string{}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+
+	return values
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultProfile is the profile read from the credentials file when no
+// profile is selected.
+const defaultProfile = "default"
+
+// errProfileNotFound is returned when the selected profile isn't present in
+// the credentials file.
+var errProfileNotFound = errors.New("profile not found")
+
+// profile holds the credentials of a named profile in a credentials file.
+type profile struct {
+	Name     string
+	APIKey   string
+	APIToken string
+}
+
+// defaultCredentialsFile returns the default credentials file location,
+// ~/.bonsai/credentials.
+func defaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".bonsai", "credentials"), nil
+}
+
+// loadProfile reads the named profile from the credentials file at path.
+//
+// The credentials file holds one section per profile, with keys assigned
+// using either INI or TOML syntax, such as:
+//
+//	[default]
+//	api_key   = "..."
+//	api_token = "..."
+func loadProfile(path, name string) (profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return profile{}, err
+	}
+	defer f.Close()
+
+	var (
+		section string
+		found   bool
+		p       = profile{Name: name}
+	)
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = unquote(strings.TrimSpace(line[1 : len(line)-1]))
+			if section == name {
+				found = true
+			}
+			continue
+		case section != name:
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return profile{}, fmt.Errorf("%s:%d: expected a key = value assignment", path, lineNumber)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "api_key":
+			p.APIKey = unquote(strings.TrimSpace(value))
+		case "api_token":
+			p.APIToken = unquote(strings.TrimSpace(value))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return profile{}, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	if !found {
+		return profile{}, fmt.Errorf("%w: %q in %s", errProfileNotFound, name, path)
+	}
+
+	return p, nil
+}
+
+// unquote removes a single pair of surrounding quotes from s, if present.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -5,18 +5,27 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
 	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
 	"github.com/omc/terraform-provider-bonsai/internal/plan"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 	"github.com/omc/terraform-provider-bonsai/internal/release"
 	"github.com/omc/terraform-provider-bonsai/internal/space"
 )
@@ -39,10 +48,35 @@ type bonsaiProvider struct {
 
 // bonsaiProviderModel maps provider schema data to a Go type.
 type bonsaiProviderModel struct {
-	APIKey   types.String `tfsdk:"api_key"`
-	APIToken types.String `tfsdk:"api_token"`
+	APIKey                     types.String  `tfsdk:"api_key"`
+	APIToken                   types.String  `tfsdk:"api_token"`
+	APIEndpoint                types.String  `tfsdk:"api_endpoint"`
+	Profile                    types.String  `tfsdk:"profile"`
+	CredentialsFile            types.String  `tfsdk:"credentials_file"`
+	CredentialProcess          types.String  `tfsdk:"credential_process"`
+	VerifyCredentials          types.Bool    `tfsdk:"verify_credentials"`
+	HTTPProxy                  types.String  `tfsdk:"http_proxy"`
+	CACertFile                 types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM                  types.String  `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify         types.Bool    `tfsdk:"insecure_skip_verify"`
+	RequestTimeout             types.String  `tfsdk:"request_timeout"`
+	MaxRequestsPerSecond       types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests      types.Int64   `tfsdk:"max_concurrent_requests"`
+	AllowedPlans               types.List    `tfsdk:"allowed_plans"`
+	AllowedSpaces              types.List    `tfsdk:"allowed_spaces"`
+	AllowedReleases            types.List    `tfsdk:"allowed_releases"`
+	MaxMonthlySpendCents       types.Int64   `tfsdk:"max_monthly_spend_cents"`
+	MaxMonthlySpendEnforcement types.String  `tfsdk:"max_monthly_spend_enforcement"`
+	ReadOnly                   types.Bool    `tfsdk:"read_only"`
+	CatalogCacheTTL            types.String  `tfsdk:"catalog_cache_ttl"`
+	DisableCatalogCache        types.Bool    `tfsdk:"disable_catalog_cache"`
+	Retry                      *retryModel   `tfsdk:"retry"`
 }
 
+// applicationName identifies the provider to the Bonsai API, alongside its
+// version, through the User-Agent header of every request.
+const applicationName = "terraform-provider-bonsai"
+
 func (p *bonsaiProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "bonsai"
 	resp.Version = p.version
@@ -53,15 +87,19 @@ func (p *bonsaiProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The Bonsai provider is used to create and manage resources on the Bonsai.io platform." + "\n" +
 			"To use the provider, you must provide both an API Access Key and Token, obtainable from within the " +
-			"[Bonsai.io](https://bonsai.io) management panel!",
-		Attributes: map[string]schema.Attribute{
+			"[Bonsai.io](https://bonsai.io) management panel!" + "\n\n" +
+			"Credentials are read from the first of the following sources to provide them: " +
+			"the `api_key` and `api_token` attributes, the output of the `credential_process` command, " +
+			"the `BONSAI_API_KEY` and `BONSAI_API_TOKEN` " +
+			"environment variables, then the selected `profile` of the credentials file.",
+		Attributes: mergeAttributes(map[string]schema.Attribute{
 			"api_key": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
 				// First line is at the bullet-point, following must be indented
 				MarkdownDescription: "Bonsai.io API Access Key." + "\n\n" +
 					"   - If not set, terraform will look for the `BONSAI_API_KEY` " +
-					"   environment variable." + "\n\n" +
+					"   environment variable, then the selected `profile`." + "\n\n" +
 					"   - Obtainable from within the management panel at " +
 					"   [Bonsai.io](https://bonsai.io)",
 			},
@@ -71,10 +109,103 @@ func (p *bonsaiProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				// First line is at the bullet-point, following must be indented
 				MarkdownDescription: "Bonsai.io API Access Token." + "\n\n" +
 					"   - If not set, terraform will look for the `BONSAI_API_TOKEN` " +
-					"   environment variable." + "\n\n" +
+					"   environment variable, then the selected `profile`." + "\n\n" +
 					"   - Obtainable from within the management panel at " +
 					"   [Bonsai.io](https://bonsai.io)",
 			},
+			"api_endpoint": schema.StringAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Bonsai.io API endpoint URL. Defaults to " +
+					"`" + bonsai.BaseEndpoint + "`." + "\n\n" +
+					"   - If not set, terraform will look for the `BONSAI_API_ENDPOINT` " +
+					"   environment variable." + "\n\n" +
+					"   - Useful for targeting a staging API, or a local " +
+					"   stand-in during testing.",
+			},
+			"credential_process": schema.StringAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Command to run to obtain the API Access Key and Token, " +
+					"such as from a secrets manager. The command is run through the system shell, " +
+					"and must write a JSON object with `api_key` and `api_token` string values, " +
+					"and optionally an RFC 3339 `expiration` time, to its standard output." + "\n\n" +
+					"   - Only consulted for credentials not set by `api_key` or `api_token`, " +
+					"   and takes precedence over environment variables and the selected `profile`." + "\n\n" +
+					"   - The command's standard error is included in diagnostics should it fail.",
+			},
+			"verify_credentials": schema.BoolAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Whether to verify the API Access Key and Token with a single " +
+					"request to the Bonsai API when the provider is configured. Defaults to `true`." + "\n\n" +
+					"   - Rejected credentials are reported once, naming the source they " +
+					"   were read from, rather than by each resource and data source.",
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "URL of the proxy to send requests to the Bonsai API through, " +
+					"such as `http://proxy.example.com:3128`." + "\n\n" +
+					"   - If not set, terraform will look for the `HTTPS_PROXY` and " +
+					"   `NO_PROXY` environment variables.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Path to a file of PEM encoded CA certificates to trust, in addition " +
+					"to the system's, such as those of an intercepting proxy.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "PEM encoded CA certificates to trust, in addition to the system's " +
+					"and those of `ca_cert_file`.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Whether to skip verification of TLS certificates. " +
+					"**Insecure**: connections, and the credentials sent over them, may be intercepted. " +
+					"Prefer `ca_cert_file` or `ca_cert_pem`. Defaults to `false`.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Maximum duration of each request to the Bonsai API, including reading " +
+					"its response, such as `\"30s\"`. Requests timing out are retried according to the " +
+					"`retry` policy. Unlimited if not set.",
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Maximum average rate of requests made to the Bonsai API, " +
+					"shared by all resources and data sources. Bursts of up to a second's worth " +
+					"of requests are permitted." + "\n\n" +
+					"   - If not set, the Bonsai API client's default rate limit applies.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Maximum number of requests in flight to the Bonsai API at once, " +
+					"shared by all resources and data sources. Unlimited if not set.",
+			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Named profile of the credentials file to read the " +
+					"API Access Key and Token from. Defaults to `" + defaultProfile + "`." + "\n\n" +
+					"   - If not set, terraform will look for the `BONSAI_PROFILE` " +
+					"   environment variable." + "\n\n" +
+					"   - Only consulted for credentials not set in the configuration " +
+					"   or environment variables.",
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional: true,
+				// First line is at the bullet-point, following must be indented
+				MarkdownDescription: "Path to the credentials file holding named profiles. " +
+					"Defaults to `~/.bonsai/credentials`." + "\n\n" +
+					"   - If not set, terraform will look for the `BONSAI_CREDENTIALS_FILE` " +
+					"   environment variable.",
+			},
+		}, policySchemaAttributes(), catalogSchemaAttributes()),
+		Blocks: map[string]schema.Block{
+			"retry": retrySchemaBlock(),
 		},
 	}
 }
@@ -91,6 +222,7 @@ func (p *bonsaiProvider) DataSources(ctx context.Context) []func() datasource.Da
 		cluster.NewListDataSource,
 		plan.NewDataSource,
 		plan.NewListDataSource,
+		plan.NewRecommendationDataSource,
 		release.NewDataSource,
 		release.NewListDataSource,
 		space.NewDataSource,
@@ -133,24 +265,48 @@ func New(options ...BonsaiProviderOption) func() provider.Provider {
 }
 
 func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	// Retrieve provider data from configuration
 	var config bonsaiProviderModel
 
-	// Bonsai API Client has already been configured; skip all client configuration
-	if p.bonsaiAPIClient != nil {
-		// Make the Bonsai client available during DataSource and resource
-		// type Configure methods.
-		resp.DataSourceData = p.bonsaiAPIClient
-		resp.ResourceData = p.bonsaiAPIClient
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	diags := req.Config.Get(ctx, &config)
+	clusterPolicy, diags := newPolicy(ctx, config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown Bonsai Read-Only Mode",
+			"The provider cannot determine whether it may modify Bonsai resources as there is an unknown configuration value for read_only. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+		return
+	}
+
+	// Bonsai API Client has already been configured; skip all client configuration
+	if p.bonsaiAPIClient != nil {
+		// Make the Bonsai client available during DataSource and resource
+		// type Configure methods.
+		data, diags := newProviderData(config, p.bonsaiAPIClient, clusterPolicy)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.DataSourceData = data
+		resp.ResourceData = data
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
@@ -172,24 +328,83 @@ func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.APIEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_endpoint"),
+			"Unknown Bonsai API Endpoint",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for the Bonsai API endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BONSAI_API_ENDPOINT environment variable.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Bonsai Credentials Profile",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for the Bonsai credentials profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BONSAI_PROFILE environment variable.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown Bonsai Credential Process",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for the Bonsai credential process. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.VerifyCredentials.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verify_credentials"),
+			"Unknown Bonsai Credential Verification",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for verify_credentials. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown Bonsai Credentials File",
+			"The provider cannot create the Bonsai API client as there is an unknown configuration value for the Bonsai credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BONSAI_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Credentials are read from the configuration, then the credential
+	// process, then environment variables, then the credentials file profile.
+	creds, diags := resolveCredentials(ctx, config)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	apiKey := creds.APIKey
+	apiToken := creds.APIToken
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	apiKey := os.Getenv("BONSAI_API_KEY")
-	apiToken := os.Getenv("BONSAI_API_TOKEN")
+	apiEndpoint := os.Getenv("BONSAI_API_ENDPOINT")
 
-	if !config.APIKey.IsNull() {
-		apiKey = config.APIKey.ValueString()
+	if !config.APIEndpoint.IsNull() {
+		apiEndpoint = config.APIEndpoint.ValueString()
 	}
 
-	if !config.APIToken.IsNull() {
-		apiToken = config.APIToken.ValueString()
+	if apiEndpoint == "" {
+		apiEndpoint = bonsai.BaseEndpoint
 	}
 
+	ctx = logging.MaskSecrets(ctx, apiKey, apiToken)
+	ctx = tflog.SetField(ctx, "api_key_source", creds.KeySource)
+	ctx = tflog.SetField(ctx, "api_token_source", creds.TokenSource)
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -198,8 +413,9 @@ func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			path.Root("api_key"),
 			"Missing Bonsai API Key",
 			"The provider cannot create the Bonsai API client as there is a missing or empty value for the Bonsai API key. "+
-				"Set the API Key value in the configuration or use the BONSAI_API_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the API Key value in the configuration, configure a credential process, use the BONSAI_API_KEY environment variable, "+
+				"or set api_key in the selected profile of the credentials file. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -208,11 +424,26 @@ func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			path.Root("api_token"),
 			"Missing Bonsai API Token",
 			"The provider cannot create the Bonsai API client as there is a missing or empty value for the Bonsai API token. "+
-				"Set the API Token value in the configuration or use the BONSAI_API_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the API Token value in the configuration, configure a credential process, use the BONSAI_API_TOKEN environment variable, "+
+				"or set api_token in the selected profile of the credentials file. "+
+				"If any is already set, ensure the value is not empty.",
+		)
+	}
+
+	if err := validateEndpoint(apiEndpoint); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_endpoint"),
+			"Invalid Bonsai API Endpoint",
+			"The provider cannot create the Bonsai API client as the Bonsai API endpoint is invalid. "+
+				"Set the API endpoint value in the configuration or the BONSAI_API_ENDPOINT environment variable "+
+				"to an absolute http(s) URL, such as "+bonsai.BaseEndpoint+".\n\n"+
+				"Error: "+err.Error(),
 		)
 	}
 
+	clientOptions, diags := httpClientOptions(config)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -223,6 +454,7 @@ func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			"Unable to Create Bonsai API Client",
 			"An unexpected error occurred when creating the Bonsai API client. "+
 				"If the error is not clear, please contact the provider developers.\n\n"+
+				"API key source: "+creds.KeySource+"\n"+
 				"Bonsai Client Error: "+err.Error(),
 		)
 		return
@@ -234,23 +466,110 @@ func (p *bonsaiProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			"Unable to Create Bonsai API Client",
 			"An unexpected error occurred when creating the Bonsai API client. "+
 				"If the error is not clear, please contact the provider developers.\n\n"+
+				"API token source: "+creds.TokenSource+"\n"+
 				"Bonsai Client Error: "+err.Error(),
 		)
 		return
 	}
 
+	ctx = tflog.SetField(ctx, "api_endpoint", apiEndpoint)
+	tflog.Debug(ctx, "Creating Bonsai API client")
+
 	// Create a new Bonsai client using the configuration values
-	client := bonsai.NewClient(
+	client := bonsai.NewClient(append(
+		clientOptions,
+		bonsai.WithEndpoint(apiEndpoint),
+		bonsai.WithApplication(
+			bonsai.Application{
+				Name:    applicationName,
+				Version: p.version,
+			},
+		),
 		bonsai.WithCredentialPair(
 			bonsai.CredentialPair{
 				AccessKey:   accessKey,
 				AccessToken: accessToken,
 			},
 		),
-	)
+	)...)
+
+	data, diags := newProviderData(config, client, clusterPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Verifying the credentials lists the plans, priming the catalog cache.
+	if config.VerifyCredentials.IsNull() || config.VerifyCredentials.ValueBool() {
+		resp.Diagnostics.Append(verifyCredentials(ctx, data.Catalog, creds)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Make the Bonsai client available during DataSource and resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
+
+	tflog.Info(ctx, "Configured Bonsai API client", map[string]interface{}{
+		"credentials_source": creds.Source(),
+	})
+}
+
+// newProviderData builds the data shared with resources and data sources,
+// according to the provider configuration.
+func newProviderData(config bonsaiProviderModel, client *bonsai.Client, clusterPolicy policy.Policy) (*providerdata.Data, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clusterCatalog, d := newCatalog(config, client)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	clusterBudget, d := newBudget(config, client, clusterCatalog)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &providerdata.Data{
+		Client:   client,
+		Catalog:  clusterCatalog,
+		Policy:   clusterPolicy,
+		Budget:   clusterBudget,
+		ReadOnly: config.ReadOnly.ValueBool(),
+	}, diags
+}
+
+// mergeAttributes returns the union of the given schema attributes.
+func mergeAttributes(attributes ...map[string]schema.Attribute) map[string]schema.Attribute {
+	merged := map[string]schema.Attribute{}
+	for _, a := range attributes {
+		maps.Copy(merged, a)
+	}
+	return merged
+}
+
+// validateEndpoint ensures that endpoint is an absolute http(s) URL.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("expected an http or https URL, got scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("expected a URL with a host, got %q", endpoint)
+	}
+
+	if u.User != nil {
+		return errors.New("credentials must not be included in the URL; use api_key and api_token instead")
+	}
+
+	return nil
 }
@@ -0,0 +1,144 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/cluster"
+	"github.com/omc/terraform-provider-bonsai/internal/provider"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+// Secrets which mustn't be logged; distinct from any other logged value.
+const (
+	testLoggedAPIKey          = "k7x2apikeyq9"
+	testLoggedAPIToken        = "t4m8apitokenz3"
+	testLoggedClusterUsername = "u5n1clusteruserw6"
+	testLoggedClusterPassword = "p2d9clusterpassv8"
+)
+
+type ProviderLoggingTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+}
+
+func TestProviderLoggingTestSuite(t *testing.T) {
+	suite.Run(t, &ProviderLoggingTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ProviderLoggingTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "sandbox",
+						"name": "Sandbox",
+						"price_in_cents": 0,
+						"billing_interval_in_months": 1,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Post(bonsai.ClusterAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`
+			{
+				"message": "Your cluster is being provisioned.",
+				"monitor": "https://app.bonsai.io/clusters/logging-1234",
+				"access": {
+					"host": "logging-1234",
+					"port": 443,
+					"scheme": "https",
+					"user": "` + testLoggedClusterUsername + `",
+					"pass": "` + testLoggedClusterPassword + `",
+					"url": "https://` + testLoggedClusterUsername + `:` + testLoggedClusterPassword + `@logging-1234.us-east-1.bonsaisearch.net:443"
+				}
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath+"/{slug}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"cluster": {
+					"slug": "logging-1234",
+					"name": "logging",
+					"plan": {"slug": "sandbox"},
+					"release": {"slug": "opensearch-2.6.0-mt", "service_type": "opensearch"},
+					"space": {"path": "omc/bonsai/us-east-1/common", "region": "aws-us-east-1"},
+					"access": {
+						"host": "logging-1234.us-east-1.bonsaisearch.net",
+						"port": 443,
+						"scheme": "https"
+					},
+					"state": "PROVISIONED"
+				}
+			}
+		`))
+	})
+}
+
+// TestProvider_CredentialsNotLogged records everything logged while the
+// provider is configured, then a cluster is created and read, asserting
+// that no credentials are logged.
+func (s *ProviderLoggingTestSuite) TestProvider_CredentialsNotLogged() {
+	sink := new(bytes.Buffer)
+	ctx := tflogtest.RootLogger(context.Background(), sink)
+
+	data, err := test.ConfigureProvider(ctx, provider.New(provider.WithVersion("0.1.0-test"))(),
+		test.Argument{Path: path.Root("api_key"), Value: testLoggedAPIKey},
+		test.Argument{Path: path.Root("api_token"), Value: testLoggedAPIToken},
+		test.Argument{Path: path.Root("api_endpoint"), Value: s.Server.URL},
+	)
+	s.NoError(err)
+
+	createResp, err := test.CreateResource(ctx, cluster.NewResource(), data,
+		test.Argument{Path: path.Root("name"), Value: "logging"},
+		test.Argument{Path: path.Root("plan").AtName("slug"), Value: "sandbox"},
+		test.Argument{Path: path.Root("space").AtName("path"), Value: "omc/bonsai/us-east-1/common"},
+		test.Argument{Path: path.Root("release").AtName("slug"), Value: "opensearch-2.6.0-mt"},
+	)
+	s.NoError(err)
+	s.False(createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	readResp, err := test.ReadResource(ctx, cluster.NewResource(), data, createResp.State)
+	s.NoError(err)
+	s.False(readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	// The credentials are logged about, but never logged themselves.
+	logged := sink.String()
+	s.Contains(logged, "api_key_source")
+	s.Contains(logged, "received cluster")
+	for _, secret := range []string{
+		testLoggedAPIKey,
+		testLoggedAPIToken,
+		testLoggedClusterUsername,
+		testLoggedClusterPassword,
+	} {
+		s.NotContains(logged, secret)
+	}
+
+	// Nor are they logged masked, which relies upon the masking to work.
+	s.NotContains(logged, `"api_key":`)
+	s.NotContains(logged, `"api_token":`)
+
+	// The credentials are still stored in state.
+	var password string
+	s.False(readResp.State.GetAttribute(ctx, path.Root("access").AtName("password"), &password).HasError())
+	s.Equal(testLoggedClusterPassword, password)
+}
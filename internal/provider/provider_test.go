@@ -0,0 +1,667 @@
+package provider_test
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testAPIKey   = "TerraformTestKey"
+	testAPIToken = "TerraformTestToken"
+
+	// testForbiddenAPIKey is accepted, but not permitted to access the API.
+	testForbiddenAPIKey = "TerraformForbiddenKey"
+)
+
+type ProviderTestSuite struct {
+	*test.ProviderMockRequestTestSuite
+}
+
+func TestProviderTestSuite(t *testing.T) {
+	suite.Run(t, &ProviderTestSuite{ProviderMockRequestTestSuite: &test.ProviderMockRequestTestSuite{}})
+}
+
+func (s *ProviderTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.ProviderMockRequestTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.PlanAPIBasePath, func(w http.ResponseWriter, r *http.Request) {
+		// Requests must identify the provider
+		if !regexp.MustCompile(`^terraform-provider-bonsai/\S+ bonsai-api-go/`).MatchString(r.UserAgent()) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors": ["unexpected user agent"], "status": 400}`))
+			return
+		}
+
+		// Requests must authenticate with the test credentials
+		if key, _, _ := r.BasicAuth(); key == testForbiddenAPIKey {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["forbidden"], "status": 403}`))
+			return
+		}
+
+		if key, token, ok := r.BasicAuth(); !ok || key != testAPIKey || token != testAPIToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors": ["unexpected credentials"], "status": 401}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"plans": [
+					{
+						"slug": "sandbox",
+						"name": "Sandbox",
+						"price_in_cents": 0,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					},
+					{
+						"slug": "standard-sm",
+						"name": "Standard Small",
+						"price_in_cents": 5000,
+						"billing_interval_in_months": 1,
+						"single_tenant": false,
+						"private_network": false,
+						"available_releases": ["opensearch-2.6.0-mt"],
+						"available_spaces": ["omc/bonsai/us-east-1/common"]
+					}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ClusterAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"clusters": [
+					{
+						"slug": "unmanaged-1234",
+						"name": "unmanaged",
+						"plan": {"slug": "standard-sm"},
+						"space": {"path": "omc/bonsai/us-east-1/common"},
+						"release": {"slug": "opensearch-2.6.0-mt"},
+						"state": "PROVISIONED"
+					}
+				]
+			}
+		`))
+	})
+}
+
+// providerConfig returns a provider configuration targeting the mock API.
+func (s *ProviderTestSuite) providerConfig() string {
+	return s.providerConfigWithBlock("")
+}
+
+// providerConfigWithBlock returns a provider configuration targeting the mock
+// API, including the given additional configuration.
+func (s *ProviderTestSuite) providerConfigWithBlock(block string) string {
+	return fmt.Sprintf(`
+		provider "bonsai" {
+			api_key      = "%s"
+			api_token    = "%s"
+			api_endpoint = "%s"
+			%s
+		}
+	`, testAPIKey, testAPIToken, s.Server.URL, block)
+}
+
+// writeCredentialsFile writes a credentials file with the given contents,
+// returning its path. Credentials from the environment are cleared, such that
+// only the configuration and credentials file are consulted.
+func (s *ProviderTestSuite) writeCredentialsFile(contents string) string {
+	for _, env := range []string{"BONSAI_API_KEY", "BONSAI_API_TOKEN", "BONSAI_PROFILE", "BONSAI_CREDENTIALS_FILE"} {
+		s.T().Setenv(env, "")
+	}
+
+	name := filepath.Join(s.T().TempDir(), "credentials")
+	s.Require().NoError(os.WriteFile(name, []byte(contents), 0o600))
+
+	return name
+}
+
+func (s *ProviderTestSuite) TestProvider_APIEndpoint() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfig() + `
+					data "bonsai_plans" "list" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.#", "2"),
+					resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+				),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_InvalidAPIEndpoint() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bonsai" {
+						api_key      = "TerraformTestKey"
+						api_token    = "TerraformTestToken"
+						api_endpoint = "api.bonsai.io"
+					}
+
+					data "bonsai_plans" "list" {}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Bonsai API Endpoint`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_Profile() {
+	credentialsFile := s.writeCredentialsFile(fmt.Sprintf(`
+		# Credentials of the default profile are rejected by the mock API.
+		[default]
+		api_key   = "DefaultKey"
+		api_token = "DefaultToken"
+
+		[staging]
+		api_key   = "%s"
+		api_token = '%s'
+	`, testAPIKey, testAPIToken))
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						profile          = "staging"
+						credentials_file = "%s"
+						api_endpoint     = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, credentialsFile, s.Server.URL),
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_ProfilePrecedence() {
+	credentialsFile := s.writeCredentialsFile(fmt.Sprintf(`
+		[default]
+		api_key   = "ProfileKey"
+		api_token = "%s"
+	`, testAPIToken))
+
+	// The configured API key takes precedence over the profile's, while the
+	// API token is read from the profile.
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key          = "%s"
+						credentials_file = "%s"
+						api_endpoint     = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIKey, credentialsFile, s.Server.URL),
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_MissingProfile() {
+	credentialsFile := s.writeCredentialsFile(`
+		[default]
+		api_key   = "DefaultKey"
+		api_token = "DefaultToken"
+	`)
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						profile          = "partner"
+						credentials_file = "%s"
+						api_endpoint     = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, credentialsFile, s.Server.URL),
+				ExpectError: regexp.MustCompile(`Unable to Read Bonsai Credentials Profile`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_CredentialProcess() {
+	// Credentials of the credential process take precedence over those of
+	// the credentials file.
+	credentialsFile := s.writeCredentialsFile(`
+		[default]
+		api_key   = "DefaultKey"
+		api_token = "DefaultToken"
+	`)
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						credential_process = <<-EOT
+							echo '{"api_key": "%s", "api_token": "%s", "expiration": "2999-01-01T00:00:00Z"}'
+						EOT
+						credentials_file   = "%s"
+						api_endpoint       = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIKey, testAPIToken, credentialsFile, s.Server.URL),
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_CredentialProcessFailure() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						credential_process = "echo 'secrets manager unavailable' >&2; exit 1"
+						api_endpoint       = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, s.Server.URL),
+				ExpectError: regexp.MustCompile(`(?s)Unable to Read Bonsai Credentials from Credential Process.*secrets manager unavailable`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						credential_process = "echo '{\"api_key\": \"%s\", \"api_token\": \"%s\", \"expiration\": \"2000-01-01T00:00:00Z\"}'"
+						api_endpoint       = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIKey, testAPIToken, s.Server.URL),
+				ExpectError: regexp.MustCompile(`credentials which expired`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_VerifyCredentials() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key      = "InvalidKey"
+						api_token    = "%s"
+						api_endpoint = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIToken, s.Server.URL),
+				ExpectError: regexp.MustCompile(`(?s)Invalid Bonsai API Credentials.*read from provider configuration`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key      = "%s"
+						api_token    = "%s"
+						api_endpoint = "%s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testForbiddenAPIKey, testAPIToken, s.Server.URL),
+				ExpectError: regexp.MustCompile(`Invalid Bonsai API Credentials`),
+			},
+			{
+				// Without verification, rejected credentials are reported by
+				// each data source.
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key            = "InvalidKey"
+						api_token          = "%s"
+						api_endpoint       = "%s"
+						verify_credentials = false
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIToken, s.Server.URL),
+				ExpectError: regexp.MustCompile(`Unable to Read Bonsai Plans`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_Retry() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfigWithBlock(`
+					retry {
+						max_attempts = 0
+						min_backoff  = "-1s"
+					}
+				`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Bonsai API Retry Policy.*Invalid Duration`),
+			},
+			{
+				Config: s.providerConfigWithBlock(`
+					retry {
+						max_attempts = 2
+						min_backoff  = "100ms"
+						max_backoff  = "1s"
+					}
+				`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_RequestLimits() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfigWithBlock(`
+					max_requests_per_second = 0
+					max_concurrent_requests = 0
+				`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Bonsai API Request Limit`),
+			},
+			{
+				Config: s.providerConfigWithBlock(`
+					max_requests_per_second = 2.5
+					max_concurrent_requests = 1
+				`) + `
+					data "bonsai_plans" "first" {}
+					data "bonsai_plans" "second" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bonsai_plans.first", "plans.0.slug", "sandbox"),
+					resource.TestCheckResourceAttr("data.bonsai_plans.second", "plans.0.slug", "sandbox"),
+				),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_HTTPProxy() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key      = "%s"
+						api_token    = "%s"
+						api_endpoint = "http://api.bonsai.invalid"
+						http_proxy   = "bonsai.invalid"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIKey, testAPIToken),
+				ExpectError: regexp.MustCompile(`Invalid HTTP Proxy`),
+			},
+			{
+				// Requests reach the mock API through it acting as a proxy.
+				Config: fmt.Sprintf(`
+					provider "bonsai" {
+						api_key         = "%s"
+						api_token       = "%s"
+						api_endpoint    = "http://api.bonsai.invalid"
+						http_proxy      = "%s"
+						request_timeout = "10s"
+					}
+
+					data "bonsai_plans" "list" {}
+				`, testAPIKey, testAPIToken, s.Server.URL),
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_CACertificates() {
+	srv := httptest.NewTLSServer(s.ServeMux)
+	defer srv.Close()
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	caCertFile := filepath.Join(s.T().TempDir(), "ca.pem")
+	s.Require().NoError(os.WriteFile(caCertFile, caCertPEM, 0o600))
+
+	config := func(tlsConfig string) string {
+		return fmt.Sprintf(`
+			provider "bonsai" {
+				api_key      = "%s"
+				api_token    = "%s"
+				api_endpoint = "%s"
+				%s
+			}
+
+			data "bonsai_plans" "list" {}
+		`, testAPIKey, testAPIToken, srv.URL, tlsConfig)
+	}
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`retry { max_attempts = 1 }`),
+				ExpectError: regexp.MustCompile(`certificate`),
+			},
+			{
+				Config:      config(`ca_cert_pem = "not a certificate"`),
+				ExpectError: regexp.MustCompile(`Invalid CA Certificate`),
+			},
+			{
+				Config: config(fmt.Sprintf(`ca_cert_pem = %q`, caCertPEM)),
+				Check:  resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+			{
+				Config: config(fmt.Sprintf(`ca_cert_file = %q`, caCertFile)),
+				Check:  resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+			{
+				Config: config(`insecure_skip_verify = true`),
+				Check:  resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_ClusterPolicy() {
+	cluster := func(planSlug, spacePath, releaseSlug string) string {
+		return fmt.Sprintf(`
+			resource "bonsai_cluster" "test" {
+				name = "policy-test"
+
+				plan = {
+					slug = %q
+				}
+
+				space = {
+					path = %q
+				}
+
+				release = {
+					slug = %q
+				}
+			}
+		`, planSlug, spacePath, releaseSlug)
+	}
+
+	policy := `
+		allowed_plans    = ["sandbox"]
+		allowed_spaces   = ["omc/bonsai/us-*"]
+		allowed_releases = ["opensearch-2.6.0-mt"]
+	`
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      s.providerConfigWithBlock(policy) + cluster("standard-sm", "omc/bonsai/us-east-1/common", "opensearch-2.6.0-mt"),
+				ExpectError: regexp.MustCompile(`(?s)Bonsai Cluster Policy Violation.*plan "standard-sm" is not permitted`),
+			},
+			{
+				Config:      s.providerConfigWithBlock(policy) + cluster("sandbox", "omc/bonsai/eu-west-1/common", "opensearch-2.6.0-mt"),
+				ExpectError: regexp.MustCompile(`(?s)space "omc/bonsai/eu-west-1/common" is not permitted.*"omc/bonsai/us-\*"`),
+			},
+			{
+				Config:      s.providerConfigWithBlock(policy) + cluster("sandbox", "omc/bonsai/us-east-1/common", "elasticsearch-7.10.2"),
+				ExpectError: regexp.MustCompile(`release "elasticsearch-7.10.2" is not permitted`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_MonthlySpendBudget() {
+	cluster := `
+		resource "bonsai_cluster" "test" {
+			name = "budget-test"
+
+			plan = {
+				slug = "standard-sm"
+			}
+
+			space = {
+				path = "omc/bonsai/us-east-1/common"
+			}
+
+			release = {
+				slug = "opensearch-2.6.0-mt"
+			}
+		}
+	`
+
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The unmanaged Cluster's 5000 cents count against the budget.
+				Config:      s.providerConfigWithBlock(`max_monthly_spend_cents = 8000`) + cluster,
+				ExpectError: regexp.MustCompile(`(?s)Bonsai Monthly Spend Budget Exceeded.*10000 cents \(\$100\.00\)`),
+			},
+			{
+				Config:      s.providerConfigWithBlock(`max_monthly_spend_cents = -1`) + cluster,
+				ExpectError: regexp.MustCompile(`Invalid Bonsai Monthly Spend Budget`),
+			},
+			{
+				Config: s.providerConfigWithBlock(`
+					max_monthly_spend_cents       = 8000
+					max_monthly_spend_enforcement = "ignore"
+				`) + cluster,
+				ExpectError: regexp.MustCompile(`Invalid Bonsai Monthly Spend Enforcement`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_ReadOnly() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfigWithBlock(`read_only = true`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+			{
+				Config: s.providerConfigWithBlock(`read_only = true`) + `
+					resource "bonsai_cluster" "test" {
+						name = "read-only-test"
+
+						plan = {
+							slug = "sandbox"
+						}
+
+						space = {
+							path = "omc/bonsai/us-east-1/common"
+						}
+
+						release = {
+							slug = "opensearch-2.6.0-mt"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Bonsai Provider is Read-Only.*Unable to create the cluster`),
+			},
+		},
+	})
+}
+
+func (s *ProviderTestSuite) TestProvider_CatalogCache() {
+	resource.Test(s.T(), resource.TestCase{
+		ProtoV6ProviderFactories: test.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: s.providerConfigWithBlock(`catalog_cache_ttl = "forever"`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+			{
+				Config: s.providerConfigWithBlock(`catalog_cache_ttl = "10m"`) + `
+					data "bonsai_plans" "list" {}
+
+					data "bonsai_plan" "sandbox" {
+						slug = "sandbox"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+					resource.TestCheckResourceAttr("data.bonsai_plan.sandbox", "name", "Sandbox"),
+				),
+			},
+			{
+				Config: s.providerConfigWithBlock(`disable_catalog_cache = true`) + `
+					data "bonsai_plans" "list" {}
+				`,
+				Check: resource.TestCheckResourceAttr("data.bonsai_plans.list", "plans.0.slug", "sandbox"),
+			},
+		},
+	})
+}
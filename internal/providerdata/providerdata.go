@@ -0,0 +1,25 @@
+// Package providerdata defines the data the provider shares with its
+// resources and data sources through their Configure methods.
+package providerdata
+
+import (
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/budget"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/policy"
+)
+
+// Data is the provider's ResourceData and DataSourceData.
+type Data struct {
+	// Client performs requests against the Bonsai API.
+	Client *bonsai.Client
+	// Catalog lists, and caches, the available Plans, Spaces and Releases.
+	Catalog *catalog.Catalog
+	// Policy restricts the Clusters which may be created or updated.
+	Policy policy.Policy
+	// Budget tracks the planned monthly spend of Clusters, if a budget is
+	// configured.
+	Budget *budget.Budget
+	// ReadOnly refuses to create, update or delete Clusters.
+	ReadOnly bool
+}
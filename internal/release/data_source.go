@@ -7,12 +7,38 @@ import (
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
+// dataSourceModel maps the data source schema data; a Release's model with
+// the arguments selecting it.
+type dataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Slug        types.String `tfsdk:"slug"`
+	ServiceType types.String `tfsdk:"service_type"`
+	Version     types.String `tfsdk:"version"`
+	MultiTenant types.Bool   `tfsdk:"multitenant"`
+
+	Latest            types.Bool   `tfsdk:"latest"`
+	VersionConstraint types.String `tfsdk:"version_constraint"`
+}
+
+// setRelease sets the attributes describing the Release.
+func (m *dataSourceModel) setRelease(r model) {
+	m.Name = r.Name
+	m.Slug = r.Slug
+	m.ServiceType = r.ServiceType
+	m.Version = r.Version
+	m.MultiTenant = r.MultiTenant
+}
+
 // dataSource is the data source implementation.
 type dataSource struct {
-	client *bonsai.ReleaseClient
+	catalog *catalog.Catalog
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -32,41 +58,126 @@ func (d *dataSource) Metadata(_ context.Context, req tfds.MetadataRequest, resp
 
 // Schema defines the schema for the data source.
 func (d *dataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *tfds.SchemaResponse) {
+	attributes := schemaAttributes()
+	attributes["slug"] = dschema.StringAttribute{
+		MarkdownDescription: "The machine-readable name for the deployment. " +
+			"Either `slug` or `latest` must be set.",
+		Computed: true,
+		Optional: true,
+	}
+	attributes["service_type"] = dschema.StringAttribute{
+		MarkdownDescription: "The service type of the deployment - for " +
+			"example, \"elasticsearch\". When `latest` is set, the service type " +
+			"to select the release of, such as `opensearch`.",
+		Computed: true,
+		Optional: true,
+	}
+	attributes["latest"] = dschema.BoolAttribute{
+		MarkdownDescription: "Whether to select the release with the newest version " +
+			"of `service_type` satisfying `version_constraint`, rather than by `slug`. " +
+			"Releases of the same version are ordered by slug, with the first being selected.",
+		Optional: true,
+	}
+	attributes["version_constraint"] = dschema.StringAttribute{
+		MarkdownDescription: "A version constraint which the release's version must " +
+			"satisfy, such as `\"~> 2.6\"` or `\">= 7.10, < 8\"`, in Terraform's " +
+			"version constraint syntax.",
+		Optional: true,
+	}
+
 	resp.Schema = dschema.Schema{
-		Attributes:          schemaAttributes(),
+		Attributes:          attributes,
 		MarkdownDescription: dataSourceMarkdownDescription,
 	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *dataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
-	var state model
+	ctx = logging.MaskCredentials(ctx)
 
-	// Fetch requested Slug from context
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("slug"), &state.Slug)...)
+	var state dataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.Slug.IsNull() {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Read Bonsai Release (%s) from the Bonsai API", state.Slug.ValueString()),
-			"expected 'slug' option to be set",
+	constraints, err := ParseConstraint(state.VersionConstraint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version_constraint"),
+			"Invalid Version Constraint",
+			err.Error(),
 		)
 		return
 	}
 
-	s, err := d.client.Release.GetBySlug(ctx, state.Slug.ValueString())
-	if err != nil {
+	latest := state.Latest.ValueBool()
+
+	var s bonsai.Release
+	switch {
+	case latest && !state.Slug.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("latest"),
+			"Conflicting Bonsai Release Selection",
+			"Only one of 'slug' or 'latest' may be set.",
+		)
+		return
+
+	case latest:
+		releases, err := d.catalog.Releases(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Bonsai Releases from the Bonsai API",
+				err.Error(),
+			)
+			return
+		}
+
+		s, err = latestRelease(releases, state.ServiceType, constraints)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Select Latest Bonsai Release",
+				fmt.Sprintf(
+					"Unable to select the latest release of service type %q satisfying version constraint %q: %s.",
+					state.ServiceType.ValueString(), state.VersionConstraint.ValueString(), err,
+				),
+			)
+			return
+		}
+
+	case state.Slug.IsNull():
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Bonsai Release (%s) from the Bonsai API", state.Slug.ValueString()),
-			err.Error(),
+			"expected 'slug' or 'latest' option to be set",
 		)
 		return
+
+	default:
+		s, err = d.catalog.Release(ctx, state.Slug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Bonsai Release (%s) from the Bonsai API", state.Slug.ValueString()),
+				err.Error(),
+			)
+			return
+		}
+
+		if len(selectReleases([]bonsai.Release{s}, state.ServiceType, constraints)) == 0 {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Bonsai Release (%s) Doesn't Match", state.Slug.ValueString()),
+				fmt.Sprintf(
+					"Release %q, of service type %q and version %q, doesn't match the configured "+
+						"service_type or version_constraint.",
+					s.Slug, s.ServiceType, s.Version,
+				),
+			)
+			return
+		}
 	}
 
 	// Map response body to model
-	state = convert(s)
+	state.setRelease(convert(s))
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -79,15 +190,15 @@ func (d *dataSource) Configure(_ context.Context, req tfds.ConfigureRequest, res
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Release
+	d.catalog = data.Catalog
 }
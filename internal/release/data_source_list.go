@@ -6,17 +6,24 @@ import (
 
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // listDataSourceModel maps the data source schema data.
 type listDataSourceModel struct {
+	ServiceType       types.String `tfsdk:"service_type"`
+	VersionConstraint types.String `tfsdk:"version_constraint"`
+
 	Releases []model `tfsdk:"releases"`
 }
 
 // listDataSource is the data source implementation.
 type listDataSource struct {
-	client *bonsai.ReleaseClient
+	catalog *catalog.Catalog
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -39,6 +46,18 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 	resp.Schema = dschema.Schema{
 		MarkdownDescription: listDataSourceMarkdownDescription,
 		Attributes: map[string]dschema.Attribute{
+			"service_type": dschema.StringAttribute{
+				MarkdownDescription: "The service type of the listed releases, such as " +
+					"`elasticsearch` or `opensearch`. Case-insensitive.",
+				Optional: true,
+			},
+			"version_constraint": dschema.StringAttribute{
+				MarkdownDescription: "A version constraint which the listed releases' versions " +
+					"must satisfy, such as `\"~> 2.6\"` or `\">= 7.10, < 8\"`, in Terraform's " +
+					"version constraint syntax. As Elasticsearch and OpenSearch are versioned " +
+					"independently, constraints are best combined with `service_type`.",
+				Optional: true,
+			},
 			"releases": dschema.ListNestedAttribute{
 				MarkdownDescription: dataSourceMarkdownDescription,
 				Computed:            true,
@@ -52,9 +71,26 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 
 // Read refreshes the Terraform state with the latest data.
 func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var state listDataSourceModel
 
-	releases, err := d.client.Release.All(ctx)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	constraints, err := ParseConstraint(state.VersionConstraint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version_constraint"),
+			"Invalid Version Constraint",
+			err.Error(),
+		)
+		return
+	}
+
+	releases, err := d.catalog.Releases(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Bonsai Releases from the Bonsai API",
@@ -64,8 +100,9 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 	}
 
 	// Map response body to model
-	for _, s := range releases {
-		releaseState := convert(s)
+	state.Releases = []model{}
+	for _, s := range selectReleases(releases, state.ServiceType, constraints) {
+		releaseState := convert(s.Release)
 		state.Releases = append(state.Releases, releaseState)
 	}
 
@@ -80,15 +117,15 @@ func (d *listDataSource) Configure(_ context.Context, req tfds.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Release
+	d.catalog = data.Catalog
 }
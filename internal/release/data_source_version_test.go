@@ -0,0 +1,137 @@
+package release_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/release"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type VersionConstraintTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestVersionConstraintTestSuite(t *testing.T) {
+	suite.Run(t, &VersionConstraintTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *VersionConstraintTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.ReleaseAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"releases": [
+					{"slug": "elasticsearch-7.2.0", "service_type": "elasticsearch", "version": "7.2.0"},
+					{"slug": "elasticsearch-7.10.2", "service_type": "elasticsearch", "version": "7.10.2"},
+					{"slug": "elasticsearch-8.1.0", "service_type": "elasticsearch", "version": "8.1.0"},
+					{"slug": "opensearch-2.6.0-mt", "service_type": "opensearch", "version": "2.6.0"},
+					{"slug": "opensearch-2.6.0", "service_type": "opensearch", "version": "2.6.0"},
+					{"slug": "opensearch-2.11.1", "service_type": "opensearch", "version": "2.11.1"},
+					{"slug": "opensearch-1.3.0", "service_type": "opensearch"}
+				]
+			}
+		`))
+	})
+
+	s.ServeMux.Get(bonsai.ReleaseAPIBasePath+"/{slug}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": ["not found"], "status": 404}`))
+	})
+}
+
+func (s *VersionConstraintTestSuite) TestListDataSource_VersionConstraint() {
+	s.Len(s.Listed(s.Read(release.NewListDataSource(), nil), path.Root("releases"), "slug"), 7)
+
+	s.Equal([]string{"opensearch-2.6.0-mt", "opensearch-2.6.0", "opensearch-2.11.1", "opensearch-1.3.0"}, s.Listed(s.Read(release.NewListDataSource(), map[string]any{
+		"service_type": "OpenSearch",
+	}), path.Root("releases"), "slug"))
+
+	// The version of the OpenSearch 1.3 release is read from its slug.
+	s.Equal([]string{"opensearch-1.3.0"}, s.Listed(s.Read(release.NewListDataSource(), map[string]any{
+		"version_constraint": ">= 1.3, < 2",
+	}), path.Root("releases"), "slug"))
+
+	// Versions are compared numerically, rather than lexically.
+	s.Equal([]string{"elasticsearch-7.10.2"}, s.Listed(s.Read(release.NewListDataSource(), map[string]any{
+		"service_type":       "elasticsearch",
+		"version_constraint": ">= 7.10, < 8",
+	}), path.Root("releases"), "slug"))
+
+	s.Equal([]string{"opensearch-2.6.0-mt", "opensearch-2.6.0", "opensearch-2.11.1"}, s.Listed(s.Read(release.NewListDataSource(), map[string]any{
+		"service_type":       "opensearch",
+		"version_constraint": "~> 2.6",
+	}), path.Root("releases"), "slug"))
+
+	s.Empty(s.Listed(s.Read(release.NewListDataSource(), map[string]any{
+		"version_constraint": ">= 9",
+	}), path.Root("releases"), "slug"))
+}
+
+func (s *VersionConstraintTestSuite) TestDataSource_Latest() {
+	s.Equal("opensearch-2.11.1", s.String(s.Read(release.NewDataSource(), map[string]any{
+		"latest":       true,
+		"service_type": "opensearch",
+	}), path.Root("slug")))
+
+	s.Equal("elasticsearch-7.10.2", s.String(s.Read(release.NewDataSource(), map[string]any{
+		"latest":             true,
+		"service_type":       "elasticsearch",
+		"version_constraint": ">= 7.10, < 8",
+	}), path.Root("slug")))
+
+	// Releases of the same version are ordered by slug.
+	s.Equal("opensearch-2.6.0", s.String(s.Read(release.NewDataSource(), map[string]any{
+		"latest":             true,
+		"service_type":       "opensearch",
+		"version_constraint": "~> 2.6.0",
+	}), path.Root("slug")))
+}
+
+func (s *VersionConstraintTestSuite) TestDataSource_LatestErrors() {
+	// Elasticsearch and OpenSearch versions aren't comparable.
+	resp := s.Read(release.NewDataSource(), map[string]any{"latest": true})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "set service_type to choose one")
+
+	resp = s.Read(release.NewDataSource(), map[string]any{
+		"latest":             true,
+		"service_type":       "opensearch",
+		"version_constraint": "~> 3.0",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Unable to Select Latest Bonsai Release", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(release.NewDataSource(), map[string]any{
+		"latest":             true,
+		"version_constraint": "newest",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Invalid Version Constraint", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = s.Read(release.NewDataSource(), map[string]any{
+		"latest": true,
+		"slug":   "opensearch-2.6.0",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Equal("Conflicting Bonsai Release Selection", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func (s *VersionConstraintTestSuite) TestDataSource_SlugWithConstraint() {
+	s.Equal("opensearch-2.6.0", s.String(s.Read(release.NewDataSource(), map[string]any{
+		"slug":               "opensearch-2.6.0",
+		"version_constraint": "~> 2.6",
+	}), path.Root("slug")))
+
+	resp := s.Read(release.NewDataSource(), map[string]any{
+		"slug":               "elasticsearch-8.1.0",
+		"version_constraint": "< 8",
+	})
+	s.True(resp.Diagnostics.HasError())
+	s.Contains(resp.Diagnostics.Errors()[0].Detail(), "doesn't match the configured")
+}
@@ -0,0 +1,141 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// slugVersionPattern matches the version embedded in a Release slug, such
+// as "2.6.0" of "opensearch-2.6.0-mt".
+var slugVersionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// errNoMatchingRelease is returned when no Release satisfies the selection
+// criteria.
+var errNoMatchingRelease = errors.New("no release matches")
+
+// parseVersion parses the version of the Release, falling back to the
+// version embedded in its slug should it not state one.
+func parseVersion(r bonsai.Release) (*version.Version, error) {
+	v := r.Version
+	if v == "" {
+		v = slugVersionPattern.FindString(r.Slug)
+	}
+
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("release %q has no valid version: %w", r.Slug, err)
+	}
+
+	return parsed, nil
+}
+
+// ParseConstraint parses a version constraint, such as "~> 2.6" or
+// ">= 7.10, < 8", returning nil if it's null. It's for use with Select.
+func ParseConstraint(constraint types.String) (version.Constraints, error) {
+	if constraint.IsNull() || constraint.IsUnknown() {
+		return nil, nil
+	}
+
+	return version.NewConstraint(constraint.ValueString())
+}
+
+// versionedRelease is a Release with its parsed version.
+type versionedRelease struct {
+	bonsai.Release
+	version *version.Version
+}
+
+// selectReleases returns the Releases of the service type, if not null,
+// whose versions satisfy the constraints, if not nil.
+//
+// Versions are only compared between Releases of the same service type,
+// as Elasticsearch and OpenSearch version independently. Releases whose
+// version can't be parsed only match in the absence of constraints.
+func selectReleases(releases []bonsai.Release, serviceType types.String, constraints version.Constraints) []versionedRelease {
+	selected := make([]versionedRelease, 0, len(releases))
+
+	for _, r := range releases {
+		if !serviceType.IsNull() && !serviceType.IsUnknown() && !strings.EqualFold(r.ServiceType, serviceType.ValueString()) {
+			continue
+		}
+
+		v, err := parseVersion(r)
+		if err != nil {
+			if constraints == nil {
+				selected = append(selected, versionedRelease{Release: r})
+			}
+			continue
+		}
+
+		if constraints != nil && !constraints.Check(v) {
+			continue
+		}
+
+		selected = append(selected, versionedRelease{Release: r, version: v})
+	}
+
+	return selected
+}
+
+// Select returns the Releases of the service type, if not null, whose
+// versions satisfy the constraints, if not nil, in their original order.
+func Select(releases []bonsai.Release, serviceType types.String, constraints version.Constraints) []bonsai.Release {
+	selected := selectReleases(releases, serviceType, constraints)
+
+	matches := make([]bonsai.Release, len(selected))
+	for i, r := range selected {
+		matches[i] = r.Release
+	}
+
+	return matches
+}
+
+// latestRelease returns the Release of the service type, if not null, with
+// the newest version satisfying the constraints, if not nil. Releases of
+// the same version are ordered by slug, with the first being returned.
+//
+// It's an error for the Releases to be of several service types, as their
+// versions aren't comparable.
+func latestRelease(releases []bonsai.Release, serviceType types.String, constraints version.Constraints) (bonsai.Release, error) {
+	candidates := slices.DeleteFunc(selectReleases(releases, serviceType, constraints), func(r versionedRelease) bool {
+		return r.version == nil
+	})
+
+	if len(candidates) == 0 {
+		return bonsai.Release{}, errNoMatchingRelease
+	}
+
+	serviceTypes := map[string]bool{}
+	for _, r := range candidates {
+		serviceTypes[strings.ToLower(r.ServiceType)] = true
+	}
+	if len(serviceTypes) > 1 {
+		names := make([]string, 0, len(serviceTypes))
+		for t := range serviceTypes {
+			names = append(names, t)
+		}
+		slices.Sort(names)
+
+		return bonsai.Release{}, fmt.Errorf(
+			"releases of several service types match (%s), whose versions can't be compared; set service_type to choose one",
+			strings.Join(names, ", "),
+		)
+	}
+
+	latest := slices.MaxFunc(candidates, func(a, b versionedRelease) int {
+		if c := a.version.Compare(b.version); c != 0 {
+			return c
+		}
+		// Prefer the lesser slug, such that ties are resolved consistently.
+		return strings.Compare(b.Slug, a.Slug)
+	})
+
+	return latest.Release, nil
+}
@@ -3,20 +3,36 @@ package space
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/filter"
+	"github.com/omc/terraform-provider-bonsai/internal/logging"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
 )
 
 // listDataSourceModel maps the data source schema data.
 type listDataSourceModel struct {
+	Filter *listFilterModel `tfsdk:"filter"`
+
 	Spaces []model `tfsdk:"spaces"`
 }
 
+// listFilterModel maps the data source's filter attribute.
+type listFilterModel struct {
+	CloudProvider  types.String   `tfsdk:"cloud_provider"`
+	Region         []types.String `tfsdk:"region"`
+	PrivateNetwork types.Bool     `tfsdk:"private_network"`
+	Tenancy        types.String   `tfsdk:"tenancy"`
+}
+
 // listDataSource is the data source implementation.
 type listDataSource struct {
-	client *bonsai.SpaceClient
+	catalog *catalog.Catalog
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -39,6 +55,33 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 	resp.Schema = dschema.Schema{
 		MarkdownDescription: listDataSourceMarkdownDescription,
 		Attributes: map[string]dschema.Attribute{
+			"filter": dschema.SingleNestedAttribute{
+				MarkdownDescription: "Criteria which the listed spaces must all match. " +
+					"Any space matches criteria which aren't set.",
+				Optional: true,
+				Attributes: map[string]dschema.Attribute{
+					"cloud_provider": dschema.StringAttribute{
+						MarkdownDescription: "The cloud provider in which the space is deployed, " +
+							"such as `aws`. Matched case-insensitively.",
+						Optional: true,
+					},
+					"region": dschema.ListAttribute{
+						MarkdownDescription: "The regions which the space may be in, such as " +
+							"`aws-us-east-1`. Each may be a glob pattern, in which `*` matches " +
+							"any sequence of characters and `?` any single character, such as `aws-us-*`.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"private_network": dschema.BoolAttribute{
+						MarkdownDescription: "Whether the space is isolated from the public Internet.",
+						Optional:            true,
+					},
+					"tenancy": dschema.StringAttribute{
+						MarkdownDescription: "The tenancy of the space, such as `common`.",
+						Optional:            true,
+					},
+				},
+			},
 			"spaces": dschema.ListNestedAttribute{
 				MarkdownDescription: dataSourceMarkdownDescription,
 				Computed:            true,
@@ -52,9 +95,16 @@ func (d *listDataSource) Schema(_ context.Context, _ tfds.SchemaRequest, resp *t
 
 // Read refreshes the Terraform state with the latest data.
 func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *tfds.ReadResponse) {
+	ctx = logging.MaskCredentials(ctx)
+
 	var state listDataSourceModel
 
-	spaces, err := d.client.Space.All(ctx)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaces, err := d.catalog.Spaces(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Bonsai Spaces from the Bonsai API",
@@ -63,7 +113,10 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 		return
 	}
 
+	spaces = slices.DeleteFunc(spaces, func(s bonsai.Space) bool { return !state.matches(s) })
+
 	// Map response body to model
+	state.Spaces = make([]model, 0, len(spaces))
 	for _, s := range spaces {
 		spaceState := convert(s)
 		state.Spaces = append(state.Spaces, spaceState)
@@ -74,21 +127,47 @@ func (d *listDataSource) Read(ctx context.Context, req tfds.ReadRequest, resp *t
 	resp.Diagnostics.Append(diags...)
 }
 
+// matches reports whether the Space matches the data source's filter.
+func (m listDataSourceModel) matches(s bonsai.Space) bool {
+	if m.Filter == nil {
+		return true
+	}
+
+	f := *m.Filter
+
+	// Spaces which don't state their networking are public.
+	return MatchesCloud(s, f.CloudProvider, f.Region) &&
+		filter.EqualBool(f.PrivateNetwork, s.PrivateNetwork != nil && *s.PrivateNetwork) &&
+		filter.Equal(f.Tenancy, tenancy(s.Path).ValueString())
+}
+
+// MatchesCloud reports whether the Space's cloud matches the cloud provider
+// and region filter values, which match any cloud if null. Spaces which
+// don't state their cloud, such as those known only by their path, match
+// neither once set.
+func MatchesCloud(s bonsai.Space, provider types.String, regions []types.String) bool {
+	if s.Cloud == nil {
+		return (provider.IsNull() || provider.IsUnknown()) && regions == nil
+	}
+
+	return filter.EqualFold(provider, s.Cloud.Provider) && filter.Glob(regions, s.Cloud.Region)
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *listDataSource) Configure(_ context.Context, req tfds.ConfigureRequest, resp *tfds.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*bonsai.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bonsai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.Data, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = &client.Space
+	d.catalog = data.Catalog
 }
@@ -0,0 +1,126 @@
+package space_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+	"github.com/omc/terraform-provider-bonsai/internal/space"
+	"github.com/omc/terraform-provider-bonsai/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListDataSourceFilterTestSuite struct {
+	*test.DataSourceTestSuite
+}
+
+func TestListDataSourceFilterTestSuite(t *testing.T) {
+	suite.Run(t, &ListDataSourceFilterTestSuite{DataSourceTestSuite: test.NewDataSourceTestSuite()})
+}
+
+func (s *ListDataSourceFilterTestSuite) SetupSuite() {
+	suite.SetupAllSuite(s.DataSourceTestSuite).SetupSuite()
+
+	s.ServeMux.Get(bonsai.SpaceAPIBasePath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", bonsai.HTTPContentTypeJSON)
+		_, _ = w.Write([]byte(`
+			{
+				"spaces": [
+					{
+						"path": "omc/bonsai/us-east-1/common",
+						"private_network": false,
+						"cloud": {"provider": "aws", "region": "aws-us-east-1"}
+					},
+					{
+						"path": "omc/bonsai/us-west-2/common",
+						"private_network": false,
+						"cloud": {"provider": "aws", "region": "aws-us-west-2"}
+					},
+					{
+						"path": "omc/bonsai/eu-west-1/common",
+						"private_network": false,
+						"cloud": {"provider": "aws", "region": "aws-eu-west-1"}
+					},
+					{
+						"path": "omc/bonsai/us-east-1/acme-vpc",
+						"private_network": true,
+						"cloud": {"provider": "aws", "region": "aws-us-east-1"}
+					},
+					{
+						"path": "omc/bonsai/us-central1/common",
+						"cloud": {"provider": "gcp", "region": "gcp-us-central1"}
+					},
+					{
+						"path": "omc/bonsai/ap-southeast-2/common"
+					}
+				]
+			}
+		`))
+	})
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_NoFilter() {
+	resp := s.Read(space.NewListDataSource(), nil)
+	s.Len(s.Listed(resp, path.Root("spaces"), "path"), 6)
+	s.Equal([]string{"common", "common", "common", "acme-vpc", "common", "common"}, s.Listed(resp, path.Root("spaces"), "tenancy"))
+}
+
+func (s *ListDataSourceFilterTestSuite) TestListDataSource_Filter() {
+	s.Equal([]string{"omc/bonsai/us-central1/common"}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.cloud_provider": "GCP",
+	}), path.Root("spaces"), "path"))
+
+	s.Equal([]string{
+		"omc/bonsai/us-east-1/common",
+		"omc/bonsai/us-west-2/common",
+		"omc/bonsai/us-east-1/acme-vpc",
+	}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.region": []string{"aws-us-*"},
+	}), path.Root("spaces"), "path"))
+
+	s.Equal([]string{
+		"omc/bonsai/us-west-2/common",
+		"omc/bonsai/eu-west-1/common",
+	}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.region":          []string{"aws-us-west-2", "aws-eu-*"},
+		"filter.private_network": false,
+	}), path.Root("spaces"), "path"))
+
+	s.Equal([]string{"omc/bonsai/us-east-1/acme-vpc"}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.private_network": true,
+	}), path.Root("spaces"), "path"))
+
+	// Spaces which don't state their networking are public.
+	s.Equal([]string{"omc/bonsai/us-central1/common"}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.cloud_provider":  "gcp",
+		"filter.private_network": false,
+		"filter.tenancy":         "common",
+	}), path.Root("spaces"), "path"))
+
+	s.Empty(s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.region": []string{},
+	}), path.Root("spaces"), "path"))
+
+	// Spaces which don't state their cloud only match when neither the cloud
+	// provider nor the region is filtered on.
+	s.Equal([]string{
+		"omc/bonsai/us-east-1/common",
+		"omc/bonsai/us-west-2/common",
+		"omc/bonsai/eu-west-1/common",
+		"omc/bonsai/us-central1/common",
+		"omc/bonsai/ap-southeast-2/common",
+	}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.private_network": false,
+	}), path.Root("spaces"), "path"))
+
+	s.Equal([]string{
+		"omc/bonsai/us-east-1/common",
+		"omc/bonsai/us-west-2/common",
+		"omc/bonsai/eu-west-1/common",
+		"omc/bonsai/us-central1/common",
+	}, s.Listed(s.Read(space.NewListDataSource(), map[string]any{
+		"filter.region":  []string{"*"},
+		"filter.tenancy": "common",
+	}), path.Root("spaces"), "path"))
+}
@@ -17,7 +17,7 @@ func (s *SpaceTestSuite) TestSpace_ListDataSource() {
 						}
 					`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.bonsai_spaces.list", "spaces.0.%", "3"),
+					resource.TestCheckResourceAttr("data.bonsai_spaces.list", "spaces.0.%", "4"),
 				),
 			},
 		},
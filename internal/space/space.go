@@ -1,6 +1,8 @@
 package space
 
 import (
+	"strings"
+
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/omc/bonsai-api-go/v2/bonsai"
@@ -24,18 +26,22 @@ type cloudProviderModel struct {
 type model struct {
 	Path           types.String `tfsdk:"path"`
 	PrivateNetwork types.Bool   `tfsdk:"private_network"`
+	Tenancy        types.String `tfsdk:"tenancy"`
 
 	Cloud cloudProviderModel `tfsdk:"cloud"`
 }
 
 func convert(s bonsai.Space) model {
 	m := model{
-		Path: types.StringValue(s.Path),
+		Path:    types.StringValue(s.Path),
+		Tenancy: tenancy(s.Path),
+	}
 
-		Cloud: cloudProviderModel{
+	if s.Cloud != nil {
+		m.Cloud = cloudProviderModel{
 			Provider: types.StringValue(s.Cloud.Provider),
 			Region:   types.StringValue(s.Cloud.Region),
-		},
+		}
 	}
 
 	if s.PrivateNetwork != nil {
@@ -45,6 +51,18 @@ func convert(s bonsai.Space) model {
 	return m
 }
 
+// tenancy parses the tenancy of a space from the last segment of its path,
+// such as "common" of "omc/bonsai/us-east-1/common", returning null if the
+// path doesn't have the expected four segments.
+func tenancy(path string) types.String {
+	segments := strings.Split(path, "/")
+	if len(segments) != 4 || segments[3] == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(segments[3])
+}
+
 func schemaAttributes() map[string]dschema.Attribute {
 	return map[string]dschema.Attribute{
 		"path": dschema.StringAttribute{
@@ -58,6 +76,11 @@ func schemaAttributes() map[string]dschema.Attribute {
 				"inaccessible from the public Internet. A VPC connection will " +
 				"be needed to communicate with a private cluster.",
 		},
+		"tenancy": dschema.StringAttribute{
+			Computed: true,
+			MarkdownDescription: "The tenancy of the server group, such as `common`, " +
+				"parsed from the last segment of its `path`.",
+		},
 		"cloud": dschema.SingleNestedAttribute{
 			MarkdownDescription: "Details about the cloud provider and region attributes.",
 			Computed:            true,
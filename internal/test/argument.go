@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Argument sets an attribute of a configuration or plan.
+type Argument struct {
+	Path  path.Path
+	Value any
+}
+
+// Arguments returns the arguments setting the attributes named by each key,
+// in which nested attributes are separated by dots, such as
+// "filter.max_price_in_cents".
+func Arguments(values map[string]any) []Argument {
+	arguments := make([]Argument, 0, len(values))
+	for name, value := range values {
+		steps := strings.Split(name, ".")
+
+		p := path.Root(steps[0])
+		for _, step := range steps[1:] {
+			p = p.AtName(step)
+		}
+
+		arguments = append(arguments, Argument{Path: p, Value: value})
+	}
+
+	return arguments
+}
+
+// setArguments sets the state to an object of its schema whose attributes
+// are null, but for the arguments.
+//
+// Configurations and plans are built as State, as only it may set
+// attributes.
+func setArguments(ctx context.Context, state *tfsdk.State, arguments []Argument) error {
+	objectType, ok := state.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		return fmt.Errorf("expected an object schema, got %T", state.Schema.Type().TerraformType(ctx))
+	}
+
+	nullAttributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	state.Raw = tftypes.NewValue(objectType, nullAttributes)
+
+	for _, argument := range arguments {
+		if diags := state.SetAttribute(ctx, argument.Path, argument.Value); diags.HasError() {
+			return fmt.Errorf("setting %s: %v", argument.Path, diags)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"context"
+	"fmt"
+
+	tfds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/omc/terraform-provider-bonsai/internal/catalog"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+)
+
+// ReadDataSource configures the data source with the provider data, then
+// reads it, without Terraform, given a configuration setting only the
+// arguments. Diagnostics reported by the read are returned in the response,
+// while failing to prepare the read returns an error.
+func ReadDataSource(ctx context.Context, d tfds.DataSource, data *providerdata.Data, arguments ...Argument) (tfds.ReadResponse, error) {
+	if configurable, ok := d.(tfds.DataSourceWithConfigure); ok {
+		var resp tfds.ConfigureResponse
+		configurable.Configure(ctx, tfds.ConfigureRequest{ProviderData: data}, &resp)
+		if resp.Diagnostics.HasError() {
+			return tfds.ReadResponse{}, fmt.Errorf("configuring data source: %v", resp.Diagnostics)
+		}
+	}
+
+	var schemaResp tfds.SchemaResponse
+	d.Schema(ctx, tfds.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return tfds.ReadResponse{}, fmt.Errorf("reading data source schema: %v", schemaResp.Diagnostics)
+	}
+
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	if err := setArguments(ctx, &config, arguments); err != nil {
+		return tfds.ReadResponse{}, err
+	}
+
+	resp := tfds.ReadResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(config.Raw.Type(), nil),
+	}}
+	d.Read(ctx, tfds.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+	return resp, nil
+}
+
+// DataSourceTestSuite is used for data source tests which read the data
+// source directly, without Terraform, against the mocked endpoints.
+type DataSourceTestSuite struct {
+	*ProviderMockRequestTestSuite
+
+	// Ctx is the context which data sources are read with.
+	Ctx context.Context
+}
+
+// NewDataSourceTestSuite returns a DataSourceTestSuite to embed in a data
+// source's test suite.
+func NewDataSourceTestSuite() *DataSourceTestSuite {
+	return &DataSourceTestSuite{ProviderMockRequestTestSuite: &ProviderMockRequestTestSuite{}}
+}
+
+func (s *DataSourceTestSuite) SetupSuite() {
+	s.ProviderMockRequestTestSuite.SetupSuite()
+
+	s.Ctx = context.Background()
+}
+
+// Read reads the data source, with the mock client and a new catalog,
+// given a configuration setting the arguments, keyed as by Arguments.
+func (s *DataSourceTestSuite) Read(d tfds.DataSource, arguments map[string]any) tfds.ReadResponse {
+	resp, err := ReadDataSource(s.Ctx, d, &providerdata.Data{
+		Client:  s.Client,
+		Catalog: catalog.New(s.Client, catalog.DefaultTTL),
+	}, Arguments(arguments)...)
+	s.NoError(err)
+
+	return resp
+}
+
+// String returns the string attribute at p of a successful read.
+func (s *DataSourceTestSuite) String(resp tfds.ReadResponse, p path.Path) string {
+	s.False(resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var value string
+	s.False(resp.State.GetAttribute(s.Ctx, p, &value).HasError())
+
+	return value
+}
+
+// Strings returns the list of strings at p of a successful read.
+func (s *DataSourceTestSuite) Strings(resp tfds.ReadResponse, p path.Path) []string {
+	s.False(resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var values []string
+	s.False(resp.State.GetAttribute(s.Ctx, p, &values).HasError())
+
+	return values
+}
+
+// Listed returns the string attribute, at the names, of each element of the
+// list at p of a successful read, such as the slug of each plan listed by a
+// data source. Listing ends at the first element without the attribute.
+//
+// Elements are read individually, as reading them whole requires the
+// unexported models of their packages.
+func (s *DataSourceTestSuite) Listed(resp tfds.ReadResponse, p path.Path, names ...string) []string {
+	s.False(resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	values := []string{}
+	for i := 0; ; i++ {
+		element := p.AtListIndex(i)
+		for _, name := range names {
+			element = element.AtName(name)
+		}
+
+		var value *string
+		if diags := resp.State.GetAttribute(s.Ctx, element, &value); diags.HasError() || value == nil {
+			return values
+		}
+		values = append(values, *value)
+	}
+}
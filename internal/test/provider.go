@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	tfrsc "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/omc/terraform-provider-bonsai/internal/providerdata"
+)
+
+// ConfigureProvider configures the provider, without Terraform, given a
+// configuration setting only the arguments, returning the data it provides
+// to resources and data sources.
+func ConfigureProvider(ctx context.Context, p tfprovider.Provider, arguments ...Argument) (*providerdata.Data, error) {
+	var schemaResp tfprovider.SchemaResponse
+	p.Schema(ctx, tfprovider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, fmt.Errorf("reading provider schema: %v", schemaResp.Diagnostics)
+	}
+
+	config := tfsdk.State{Schema: schemaResp.Schema}
+	if err := setArguments(ctx, &config, arguments); err != nil {
+		return nil, err
+	}
+
+	var resp tfprovider.ConfigureResponse
+	p.Configure(ctx, tfprovider.ConfigureRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, fmt.Errorf("configuring provider: %v", resp.Diagnostics)
+	}
+
+	data, ok := resp.ResourceData.(*providerdata.Data)
+	if !ok {
+		return nil, fmt.Errorf("expected *providerdata.Data, got %T", resp.ResourceData)
+	}
+
+	return data, nil
+}
+
+// CreateResource configures the resource with the provider data, then
+// creates it, without Terraform, given a plan setting only the arguments.
+// Diagnostics reported by the create are returned in the response, while
+// failing to prepare the create returns an error.
+func CreateResource(ctx context.Context, r tfrsc.Resource, data *providerdata.Data, arguments ...Argument) (tfrsc.CreateResponse, error) {
+	plan, err := configureResource(ctx, r, data)
+	if err != nil {
+		return tfrsc.CreateResponse{}, err
+	}
+
+	if err := setArguments(ctx, &plan, arguments); err != nil {
+		return tfrsc.CreateResponse{}, err
+	}
+
+	resp := tfrsc.CreateResponse{State: tfsdk.State{
+		Schema: plan.Schema,
+		Raw:    tftypes.NewValue(plan.Raw.Type(), nil),
+	}}
+	r.Create(ctx, tfrsc.CreateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+	}, &resp)
+
+	return resp, nil
+}
+
+// ResourcePlan describes a planned change to a resource, as Terraform
+// would request it be modified.
+type ResourcePlan struct {
+	// Prior sets the arguments of the prior state, which is null if none
+	// are set, as when the resource is yet to be created.
+	Prior []Argument
+	// Plan sets the arguments of the plan.
+	Plan []Argument
+	// RequiresReplace are the paths whose attribute plan modifiers require
+	// the resource to be replaced, which the framework gathers before
+	// modifying the plan.
+	RequiresReplace path.Paths
+}
+
+// PlanResource configures the resource with the provider data, then
+// modifies its plan, without Terraform. Diagnostics reported by the
+// modification are returned in the response, while failing to prepare it
+// returns an error.
+func PlanResource(ctx context.Context, r tfrsc.Resource, data *providerdata.Data, change ResourcePlan) (tfrsc.ModifyPlanResponse, error) {
+	plan, err := configureResource(ctx, r, data)
+	if err != nil {
+		return tfrsc.ModifyPlanResponse{}, err
+	}
+
+	if err := setArguments(ctx, &plan, change.Plan); err != nil {
+		return tfrsc.ModifyPlanResponse{}, err
+	}
+
+	prior := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+	if change.Prior != nil {
+		if err := setArguments(ctx, &prior, change.Prior); err != nil {
+			return tfrsc.ModifyPlanResponse{}, err
+		}
+	}
+
+	modifiable, ok := r.(tfrsc.ResourceWithModifyPlan)
+	if !ok {
+		return tfrsc.ModifyPlanResponse{}, fmt.Errorf("expected tfrsc.ResourceWithModifyPlan, got %T", r)
+	}
+
+	resp := tfrsc.ModifyPlanResponse{
+		Plan:            tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		RequiresReplace: change.RequiresReplace,
+	}
+	modifiable.ModifyPlan(ctx, tfrsc.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		State:  prior,
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+	}, &resp)
+
+	return resp, nil
+}
+
+// ReadResource configures the resource with the provider data, then reads
+// it, without Terraform, given its state.
+func ReadResource(ctx context.Context, r tfrsc.Resource, data *providerdata.Data, state tfsdk.State) (tfrsc.ReadResponse, error) {
+	if _, err := configureResource(ctx, r, data); err != nil {
+		return tfrsc.ReadResponse{}, err
+	}
+
+	resp := tfrsc.ReadResponse{State: state}
+	r.Read(ctx, tfrsc.ReadRequest{State: state}, &resp)
+
+	return resp, nil
+}
+
+// DeleteResource configures the resource with the provider data, then
+// deletes it, without Terraform, given a state setting only the arguments.
+// Diagnostics reported by the delete are returned in the response, while
+// failing to prepare the delete returns an error.
+func DeleteResource(ctx context.Context, r tfrsc.Resource, data *providerdata.Data, arguments ...Argument) (tfrsc.DeleteResponse, error) {
+	state, err := configureResource(ctx, r, data)
+	if err != nil {
+		return tfrsc.DeleteResponse{}, err
+	}
+
+	if err := setArguments(ctx, &state, arguments); err != nil {
+		return tfrsc.DeleteResponse{}, err
+	}
+
+	resp := tfrsc.DeleteResponse{State: state}
+	r.Delete(ctx, tfrsc.DeleteRequest{State: state}, &resp)
+
+	return resp, nil
+}
+
+// configureResource configures the resource with the provider data,
+// returning an empty State of its schema.
+func configureResource(ctx context.Context, r tfrsc.Resource, data *providerdata.Data) (tfsdk.State, error) {
+	if configurable, ok := r.(tfrsc.ResourceWithConfigure); ok {
+		var resp tfrsc.ConfigureResponse
+		configurable.Configure(ctx, tfrsc.ConfigureRequest{ProviderData: data}, &resp)
+		if resp.Diagnostics.HasError() {
+			return tfsdk.State{}, fmt.Errorf("configuring resource: %v", resp.Diagnostics)
+		}
+	}
+
+	var schemaResp tfrsc.SchemaResponse
+	r.Schema(ctx, tfrsc.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return tfsdk.State{}, fmt.Errorf("reading resource schema: %v", schemaResp.Diagnostics)
+	}
+
+	return tfsdk.State{Schema: schemaResp.Schema}, nil
+}
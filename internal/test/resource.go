@@ -94,18 +94,21 @@ func (s *ProviderTestSuite) SetupSuite() {
 // without requiring live responses from the production Bonsai API.
 type ProviderMockRequestTestSuite struct {
 	ClientTestSuite
-	serveMux *chi.Mux
-	server   *httptest.Server
+
+	// ServeMux routes requests to the mocked endpoints registered by tests.
+	ServeMux *chi.Mux
+	// Server is the mock Bonsai API, available at Server.URL.
+	Server *httptest.Server
 }
 
 func (s *ProviderMockRequestTestSuite) SetupSuite() {
 	version := "0.1.0-test"
 
 	// Configure http client and other miscellany
-	s.serveMux = chi.NewRouter()
-	s.server = httptest.NewServer(s.serveMux)
+	s.ServeMux = chi.NewRouter()
+	s.Server = httptest.NewServer(s.ServeMux)
 	s.Client = bonsai.NewClient(
-		bonsai.WithEndpoint(s.server.URL),
+		bonsai.WithEndpoint(s.Server.URL),
 		bonsai.WithApplication(
 			bonsai.Application{
 				Name:    "terraform-provider-bonsai",
@@ -135,3 +138,7 @@ func (s *ProviderMockRequestTestSuite) SetupSuite() {
 	// configure testify
 	s.Assertions = require.New(s.T())
 }
+
+func (s *ProviderMockRequestTestSuite) TearDownSuite() {
+	s.Server.Close()
+}
@@ -0,0 +1,77 @@
+package transport
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// Limit is an http.RoundTripper which limits the rate of requests, and the
+// number of requests in flight at once, across every request it performs.
+//
+// Limit returns an error should the request's context end while awaiting
+// capacity, so it should be wrapped by Retry when used by bonsai.Client.
+type Limit struct {
+	base     http.RoundTripper
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+// NewLimit returns a Limit performing requests with base, at no more than
+// requestsPerSecond on average, and no more than maxInFlight at once. Either
+// limit is disabled when not positive.
+func NewLimit(base http.RoundTripper, requestsPerSecond float64, maxInFlight int) *Limit {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	l := &Limit{base: base}
+
+	if requestsPerSecond > 0 {
+		// Permit bursts of up to a second's worth of requests.
+		burst := max(int(math.Ceil(requestsPerSecond)), 1)
+		l.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+
+	return l
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Limit) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+			defer func() { <-t.inFlight }()
+		default:
+			tflog.Debug(ctx, "Awaiting capacity for Bonsai API request", map[string]interface{}{
+				"method":        req.Method,
+				"path":          req.URL.Path,
+				"max_in_flight": cap(t.inFlight),
+			})
+
+			select {
+			case t.inFlight <- struct{}{}:
+				defer func() { <-t.inFlight }()
+			case <-ctx.Done():
+				return nil, fmt.Errorf("awaiting request capacity: %w", ctx.Err())
+			}
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("awaiting request rate limit: %w", err)
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
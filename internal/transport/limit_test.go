@@ -0,0 +1,98 @@
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omc/terraform-provider-bonsai/internal/transport"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LimitTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(LimitTestSuite))
+}
+
+func (s *LimitTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// get performs n concurrent GET requests to url using client.
+func (s *LimitTestSuite) get(client *http.Client, url string, n int) {
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(url)
+			if s.Assert().NoError(err) {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *LimitTestSuite) TestLimit_MaxInFlight() {
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	s.get(&http.Client{Transport: transport.NewLimit(nil, 0, 2)}, srv.URL, 8)
+	s.Equal(2, peak)
+}
+
+func (s *LimitTestSuite) TestLimit_RequestsPerSecond() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// A burst of 20 requests is permitted immediately, while the remaining
+	// 10 are spread across half a second.
+	start := time.Now()
+	s.get(&http.Client{Transport: transport.NewLimit(nil, 20, 0)}, srv.URL, 30)
+	s.GreaterOrEqual(time.Since(start), 400*time.Millisecond)
+}
+
+func (s *LimitTestSuite) TestLimit_ContextCancelled() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: transport.NewLimit(nil, 1, 0)}
+
+	// The first request consumes the burst, so the next must wait a second.
+	resp, err := client.Get(srv.URL)
+	s.NoError(err)
+	_ = resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	s.NoError(err)
+
+	_, err = client.Do(req)
+	s.ErrorContains(err, "awaiting request rate limit")
+}
@@ -0,0 +1,260 @@
+// Package transport provides http.RoundTripper middleware wrapping the HTTP
+// transport of the provider's Bonsai API client.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/omc/bonsai-api-go/v2/bonsai"
+)
+
+// Default retry configuration, used when a Retry leaves them unset.
+const (
+	DefaultMaxAttempts = 4
+	DefaultMinBackoff  = 1 * time.Second
+	DefaultMaxBackoff  = 30 * time.Second
+	DefaultJitter      = 0.1
+)
+
+// idempotentMethods may be retried after any transient failure, as repeating
+// them has no further effect.
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// Retry is an http.RoundTripper which retries requests failing transiently.
+//
+// Idempotent requests are retried after network errors, and 429, 502, 503 and
+// 504 responses. Other requests are only retried after 429 responses, which
+// the API rejects before processing them.
+//
+// Between attempts, Retry backs off exponentially from MinBackoff up to
+// MaxBackoff, unless the response's Retry-After header asks for longer.
+//
+// Failures remaining after the final attempt are reported as responses
+// rather than errors, as bonsai.Client neither survives transport errors nor
+// stops retrying 429 responses on its own.
+type Retry struct {
+	// Base performs each attempt. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// MaxAttempts is the maximum number of attempts made per request,
+	// including the first.
+	MaxAttempts int
+	// MinBackoff is the duration to wait after the first failed attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential growth of the backoff duration.
+	MaxBackoff time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	body, err := replayableBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := t.minBackoff()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.base().RoundTrip(attemptReq)
+
+		retryable, reason := t.retryable(req, resp, err)
+		if !retryable {
+			return t.result(req, resp, err, attempt)
+		}
+
+		if attempt >= t.maxAttempts() {
+			return t.result(req, resp, err, attempt)
+		}
+
+		delay := max(jitter(backoff), retryAfter(resp))
+
+		tflog.Warn(ctx, "Retrying Bonsai API request", map[string]interface{}{
+			"method":   req.Method,
+			"path":     req.URL.Path,
+			"attempt":  attempt,
+			"reason":   reason,
+			"retry_in": delay.String(),
+		})
+
+		if resp != nil {
+			// Drain the body, such that the connection may be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return errorResponse(req, http.StatusServiceUnavailable, err), nil
+		}
+
+		backoff = min(backoff*2, t.maxBackoff())
+	}
+}
+
+// retryable reports whether an attempt may be retried, and why.
+func (t *Retry) retryable(req *http.Request, resp *http.Response, err error) (bool, string) {
+	idempotent := slices.Contains(idempotentMethods, req.Method)
+
+	switch {
+	case err != nil:
+		if req.Context().Err() != nil {
+			return false, ""
+		}
+		return idempotent, err.Error()
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true, resp.Status
+	case resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+		return idempotent, resp.Status
+	default:
+		return false, ""
+	}
+}
+
+// result returns the outcome of the final attempt of a request.
+func (t *Retry) result(req *http.Request, resp *http.Response, err error, attempts int) (*http.Response, error) {
+	if err != nil {
+		return errorResponse(req, http.StatusServiceUnavailable, fmt.Errorf("request failed after %d attempt(s): %w", attempts, err)), nil
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return errorResponse(req, http.StatusServiceUnavailable, fmt.Errorf("rate limited by the Bonsai API after %d attempt(s)", attempts)), nil
+	}
+
+	return resp, nil
+}
+
+func (t *Retry) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Retry) maxAttempts() int {
+	if t.MaxAttempts > 0 {
+		return t.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
+
+func (t *Retry) minBackoff() time.Duration {
+	if t.MinBackoff > 0 {
+		return t.MinBackoff
+	}
+	return DefaultMinBackoff
+}
+
+func (t *Retry) maxBackoff() time.Duration {
+	if t.MaxBackoff > 0 {
+		return max(t.MaxBackoff, t.minBackoff())
+	}
+	return max(DefaultMaxBackoff, t.minBackoff())
+}
+
+// replayableBody reads req's body, if any, such that it may be sent with each
+// attempt.
+func replayableBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	return body, nil
+}
+
+// retryAfter returns the delay requested by resp's Retry-After header, given
+// in either seconds or as an HTTP date.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	value := strings.TrimSpace(resp.Header.Get(bonsai.HeaderRetryAfter))
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
+
+// errorResponse builds a response reporting err in the Bonsai API's error
+// format, for failures which prevent a response from being received.
+func errorResponse(req *http.Request, status int, err error) *http.Response {
+	body := fmt.Sprintf(`{"errors":[%s],"status":%d}`, strconv.Quote(err.Error()), status)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{bonsai.HTTPContentTypeJSON}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// jitter randomizes d by up to DefaultJitter, in either direction, to avoid
+// concurrent requests retrying in lockstep.
+func jitter(d time.Duration) time.Duration {
+	//nolint:gosec // Jitter needn't be cryptographically secure.
+	delta := (rand.Float64()*2 - 1) * DefaultJitter * float64(d)
+	return d + time.Duration(delta)
+}
+
+// sleep waits for d, returning early with an error if ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
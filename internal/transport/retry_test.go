@@ -0,0 +1,154 @@
+package transport_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/omc/terraform-provider-bonsai/internal/transport"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// server starts a test server responding with each of the given status codes
+// in turn, repeating the last one thereafter, and records the bodies of the
+// requests it receives.
+func (s *RetryTestSuite) server(statuses ...int) (*httptest.Server, *[]string) {
+	var (
+		calls  atomic.Int32
+		bodies []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		i := min(int(calls.Add(1))-1, len(statuses)-1)
+		w.WriteHeader(statuses[i])
+	}))
+	s.T().Cleanup(srv.Close)
+
+	return srv, &bodies
+}
+
+func (s *RetryTestSuite) client() *http.Client {
+	return &http.Client{
+		Transport: &transport.Retry{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  5 * time.Millisecond,
+		},
+	}
+}
+
+func (s *RetryTestSuite) TestRetry_RetriesIdempotentRequests() {
+	srv, bodies := s.server(http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK)
+
+	resp, err := s.client().Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Len(*bodies, 3)
+}
+
+func (s *RetryTestSuite) TestRetry_GivesUpAfterMaxAttempts() {
+	srv, bodies := s.server(http.StatusBadGateway)
+
+	resp, err := s.client().Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadGateway, resp.StatusCode)
+	s.Len(*bodies, 3)
+}
+
+func (s *RetryTestSuite) TestRetry_DoesNotRetryNonIdempotentRequests() {
+	srv, bodies := s.server(http.StatusBadGateway, http.StatusOK)
+
+	resp, err := s.client().Post(srv.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadGateway, resp.StatusCode)
+	s.Len(*bodies, 1)
+}
+
+func (s *RetryTestSuite) TestRetry_RetriesRateLimitedRequestsWithBody() {
+	srv, bodies := s.server(http.StatusTooManyRequests, http.StatusAccepted)
+
+	resp, err := s.client().Post(srv.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusAccepted, resp.StatusCode)
+	s.Equal([]string{`{"name":"test"}`, `{"name":"test"}`}, *bodies)
+}
+
+func (s *RetryTestSuite) TestRetry_ReportsExhaustedRateLimitAsError() {
+	srv, bodies := s.server(http.StatusTooManyRequests)
+
+	resp, err := s.client().Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.NoError(err)
+
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	s.Contains(string(body), "rate limited by the Bonsai API after 3 attempt(s)")
+	s.Len(*bodies, 3)
+}
+
+func (s *RetryTestSuite) TestRetry_HonoursRetryAfter() {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	resp, err := s.client().Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.GreaterOrEqual(time.Since(start), time.Second)
+}
+
+func (s *RetryTestSuite) TestRetry_ReportsNetworkErrorsAsResponses() {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	resp, err := s.client().Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.NoError(err)
+
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	s.Contains(string(body), "request failed after 3 attempt(s)")
+}
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Timeout is an http.RoundTripper which bounds the duration of each request,
+// including reading its response body.
+//
+// Timeout returns an error should the request time out, so it should be
+// wrapped by Retry when used by bonsai.Client.
+type Timeout struct {
+	// Base performs each request. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Timeout is the maximum duration of each request, if positive.
+	Timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Timeout) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t.Timeout <= 0 {
+		return base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout continues to apply while the body is read, and is released
+	// once it's closed.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnClose cancels a context once the wrapped body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
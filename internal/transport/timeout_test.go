@@ -0,0 +1,59 @@
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/omc/terraform-provider-bonsai/internal/transport"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeoutTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeoutTestSuite))
+}
+
+func (s *TimeoutTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *TimeoutTestSuite) TestTimeout_ReadsResponse() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"plans": []}`))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &transport.Timeout{Timeout: time.Second}}
+
+	resp, err := client.Get(srv.URL)
+	s.NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.NoError(err)
+	s.Equal(`{"plans": []}`, string(body))
+}
+
+func (s *TimeoutTestSuite) TestTimeout_Exceeded() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &transport.Timeout{Timeout: 50 * time.Millisecond}}
+
+	_, err := client.Get(srv.URL)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
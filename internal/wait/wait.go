@@ -0,0 +1,176 @@
+// Package wait provides a context-aware poller, used to await remote
+// resources transitioning between states.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Default backoff configuration, used when a Waiter leaves them unset.
+const (
+	DefaultMinBackoff = 2 * time.Second
+	DefaultMaxBackoff = 30 * time.Second
+	DefaultJitter     = 0.1
+)
+
+// ErrTimeout is returned when the Waiter's Timeout elapses before a target
+// state is reached.
+var ErrTimeout = errors.New("timed out while awaiting state")
+
+// UnexpectedStateError is returned when a refresh reports a state which is
+// neither pending nor targeted.
+type UnexpectedStateError struct {
+	State    string
+	Expected []string
+}
+
+func (e UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state %q, wanted one of %v", e.State, e.Expected)
+}
+
+// RefreshFunc fetches the current representation of a resource, along with
+// the Waiter state it's currently in.
+type RefreshFunc[T any] func(ctx context.Context) (T, string, error)
+
+// ProgressFunc is notified after every refresh, typically for logging.
+type ProgressFunc func(ctx context.Context, attempt int, state string, next time.Duration)
+
+// Waiter polls Refresh until it reports one of the Target states.
+//
+// Between attempts, the Waiter backs off exponentially from MinBackoff up to
+// MaxBackoff, randomized by Jitter. Waiting stops early if the context is
+// cancelled, and fails if Timeout elapses.
+type Waiter[T any] struct {
+	// Pending lists the states which are expected while waiting. If empty,
+	// any state that isn't a Target is considered pending.
+	Pending []string
+	// Target lists the states which complete the wait.
+	Target []string
+	// Refresh fetches the current state.
+	Refresh RefreshFunc[T]
+
+	// Timeout is the maximum duration to wait for, if positive.
+	Timeout time.Duration
+	// Delay is an initial duration to wait before the first refresh.
+	Delay time.Duration
+	// MinBackoff is the duration to wait after the first pending refresh.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential growth of the backoff duration.
+	MaxBackoff time.Duration
+	// Jitter randomizes each backoff by up to this fraction, in either
+	// direction, to avoid concurrent Waiters polling in lockstep.
+	Jitter float64
+
+	// OnProgress is an optional callback, notified after every refresh.
+	OnProgress ProgressFunc
+}
+
+// Wait blocks until Refresh reports a Target state, returning the last
+// refreshed value. The last refreshed value is also returned alongside any
+// error, such that callers may record partial progress.
+func (w *Waiter[T]) Wait(ctx context.Context) (T, error) {
+	var last T
+
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	if err := sleep(ctx, w.Delay); err != nil {
+		return last, w.contextError(ctx, err)
+	}
+
+	backoff := w.minBackoff()
+	for attempt := 1; ; attempt++ {
+		result, state, err := w.Refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, w.contextError(ctx, ctx.Err())
+			}
+			return last, err
+		}
+		last = result
+
+		if slices.Contains(w.Target, state) {
+			if w.OnProgress != nil {
+				w.OnProgress(ctx, attempt, state, 0)
+			}
+			return last, nil
+		}
+
+		if len(w.Pending) > 0 && !slices.Contains(w.Pending, state) {
+			return last, UnexpectedStateError{
+				State:    state,
+				Expected: append(slices.Clone(w.Pending), w.Target...),
+			}
+		}
+
+		next := w.jitter(backoff)
+		if w.OnProgress != nil {
+			w.OnProgress(ctx, attempt, state, next)
+		}
+
+		if err := sleep(ctx, next); err != nil {
+			return last, w.contextError(ctx, err)
+		}
+
+		backoff = min(backoff*2, w.maxBackoff())
+	}
+}
+
+// contextError distinguishes the Waiter's own Timeout elapsing from the
+// caller's context ending.
+func (w *Waiter[T]) contextError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && w.Timeout > 0 {
+		return fmt.Errorf("%w after %s: %w", ErrTimeout, w.Timeout, context.Cause(ctx))
+	}
+	return err
+}
+
+func (w *Waiter[T]) minBackoff() time.Duration {
+	if w.MinBackoff > 0 {
+		return w.MinBackoff
+	}
+	return DefaultMinBackoff
+}
+
+func (w *Waiter[T]) maxBackoff() time.Duration {
+	if w.MaxBackoff > 0 {
+		return max(w.MaxBackoff, w.minBackoff())
+	}
+	return max(DefaultMaxBackoff, w.minBackoff())
+}
+
+func (w *Waiter[T]) jitter(d time.Duration) time.Duration {
+	jitter := w.Jitter
+	if jitter <= 0 {
+		jitter = DefaultJitter
+	}
+
+	//nolint:gosec // Jitter needn't be cryptographically secure.
+	delta := (rand.Float64()*2 - 1) * jitter * float64(d)
+	return d + time.Duration(delta)
+}
+
+// sleep waits for d, returning early with an error if ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/omc/terraform-provider-bonsai/internal/wait"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WaitTestSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWaitTestSuite(t *testing.T) {
+	suite.Run(t, new(WaitTestSuite))
+}
+
+func (s *WaitTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// states returns a RefreshFunc which reports each of the given states in
+// turn, repeating the last one thereafter.
+func states(seq ...string) (wait.RefreshFunc[int], *int) {
+	calls := 0
+	return func(_ context.Context) (int, string, error) {
+		i := min(calls, len(seq)-1)
+		calls++
+		return calls, seq[i], nil
+	}, &calls
+}
+
+func (s *WaitTestSuite) TestWait_ReachesTarget() {
+	refresh, calls := states("pending", "pending", "done")
+	progress := []string{}
+
+	w := &wait.Waiter[int]{
+		Pending:    []string{"pending"},
+		Target:     []string{"done"},
+		Refresh:    refresh,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+		OnProgress: func(_ context.Context, _ int, state string, _ time.Duration) {
+			progress = append(progress, state)
+		},
+	}
+
+	result, err := w.Wait(context.Background())
+	s.NoError(err)
+	s.Equal(3, result)
+	s.Equal(3, *calls)
+	s.Equal([]string{"pending", "pending", "done"}, progress)
+}
+
+func (s *WaitTestSuite) TestWait_UnexpectedState() {
+	refresh, _ := states("pending", "failed")
+
+	w := &wait.Waiter[int]{
+		Pending:    []string{"pending"},
+		Target:     []string{"done"},
+		Refresh:    refresh,
+		MinBackoff: time.Millisecond,
+	}
+
+	result, err := w.Wait(context.Background())
+	s.ErrorAs(err, &wait.UnexpectedStateError{})
+	s.Equal(2, result)
+}
+
+func (s *WaitTestSuite) TestWait_RefreshError() {
+	refreshErr := errors.New("boom")
+
+	w := &wait.Waiter[int]{
+		Target: []string{"done"},
+		Refresh: func(_ context.Context) (int, string, error) {
+			return 0, "", refreshErr
+		},
+	}
+
+	_, err := w.Wait(context.Background())
+	s.ErrorIs(err, refreshErr)
+}
+
+func (s *WaitTestSuite) TestWait_Timeout() {
+	refresh, _ := states("pending")
+
+	w := &wait.Waiter[int]{
+		Target:     []string{"done"},
+		Refresh:    refresh,
+		Timeout:    20 * time.Millisecond,
+		MinBackoff: 5 * time.Millisecond,
+	}
+
+	_, err := w.Wait(context.Background())
+	s.ErrorIs(err, wait.ErrTimeout)
+}
+
+func (s *WaitTestSuite) TestWait_Cancellation() {
+	refresh, calls := states("pending")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &wait.Waiter[int]{
+		Target:     []string{"done"},
+		Refresh:    refresh,
+		MinBackoff: time.Hour,
+		OnProgress: func(_ context.Context, _ int, _ string, _ time.Duration) {
+			cancel()
+		},
+	}
+
+	start := time.Now()
+	_, err := w.Wait(ctx)
+	s.ErrorIs(err, context.Canceled)
+	s.NotErrorIs(err, wait.ErrTimeout)
+	s.Less(time.Since(start), time.Second)
+	s.Equal(1, *calls)
+}
+
+func (s *WaitTestSuite) TestWait_BackoffIsBounded() {
+	refresh, _ := states("pending", "pending", "pending", "pending", "done")
+	delays := []time.Duration{}
+
+	w := &wait.Waiter[int]{
+		Target:     []string{"done"},
+		Refresh:    refresh,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 4 * time.Millisecond,
+		Jitter:     0.1,
+		OnProgress: func(_ context.Context, _ int, _ string, next time.Duration) {
+			delays = append(delays, next)
+		},
+	}
+
+	_, err := w.Wait(context.Background())
+	s.NoError(err)
+	s.Len(delays, 5)
+
+	expected := []time.Duration{1, 2, 4, 4}
+	for i, d := range expected {
+		s.InDelta(float64(d*time.Millisecond), float64(delays[i]), 0.1*float64(d*time.Millisecond))
+	}
+	s.Zero(delays[4])
+}